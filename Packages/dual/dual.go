@@ -0,0 +1,212 @@
+package dual
+
+import (
+    "math"
+)
+
+// Dual is a second order dual number (a truncated Taylor series) used for forward-mode automatic differentiation.
+// Val holds the function value, D1 the first derivative and D2 the second derivative with respect to the variable.
+type Dual struct {
+    Val float64
+    D1  float64
+    D2  float64
+}
+
+// Var returns the independent variable x as a dual number (dx/dx = 1)
+func Var(x float64) Dual {
+    return Dual{x, 1.0, 0.0}
+}
+
+// Const returns the constant c as a dual number (all derivatives are zero)
+func Const(c float64) Dual {
+    return Dual{c, 0.0, 0.0}
+}
+
+// chain applies a scalar function with value g, first derivative dg and second derivative d2g (all evaluated at x.Val) to x
+func (x Dual) chain(g float64, dg float64, d2g float64) Dual {
+    return Dual{g, dg*x.D1, dg*x.D2 + d2g*x.D1*x.D1}
+}
+
+// Add returns x + y
+func (x Dual) Add(y Dual) Dual {
+    return Dual{x.Val + y.Val, x.D1 + y.D1, x.D2 + y.D2}
+}
+
+// Sub returns x - y
+func (x Dual) Sub(y Dual) Dual {
+    return Dual{x.Val - y.Val, x.D1 - y.D1, x.D2 - y.D2}
+}
+
+// Mul returns x * y
+func (x Dual) Mul(y Dual) Dual {
+    return Dual{x.Val*y.Val, x.D1*y.Val + x.Val*y.D1, x.D2*y.Val + 2.0*x.D1*y.D1 + x.Val*y.D2}
+}
+
+// Div returns x / y
+func (x Dual) Div(y Dual) Dual {
+    return x.Mul(Inv(y))
+}
+
+// Neg returns -x
+func (x Dual) Neg() Dual {
+    return Dual{-x.Val, -x.D1, -x.D2}
+}
+
+// Scale returns c * x
+func (x Dual) Scale(c float64) Dual {
+    return Dual{c*x.Val, c*x.D1, c*x.D2}
+}
+
+// Shift returns x + c
+func (x Dual) Shift(c float64) Dual {
+    return Dual{x.Val + c, x.D1, x.D2}
+}
+
+// Inv returns 1 / x
+func Inv(x Dual) Dual {
+    v := 1.0/x.Val
+    return x.chain(v, -v*v, 2.0*v*v*v)
+}
+
+// Sqrt returns the square root of x
+func Sqrt(x Dual) Dual {
+    s := math.Sqrt(x.Val)
+    return x.chain(s, 0.5/s, -0.25/(s*x.Val))
+}
+
+// Pow returns x**p for a real exponent p, the exponents 0, 1 and 2 are exact also at x = 0
+func Pow(x Dual, p float64) Dual {
+    switch p {
+    case 0.0:
+        return Const(1.0)
+    case 1.0:
+        return x
+    case 2.0:
+        return x.Mul(x)
+    }
+    return x.chain(math.Pow(x.Val, p), p*math.Pow(x.Val, p - 1.0), p*(p - 1.0)*math.Pow(x.Val, p - 2.0))
+}
+
+// PowD returns x**y where both the base and the exponent are dual numbers, x must be positive
+func PowD(x Dual, y Dual) Dual {
+    return Exp(y.Mul(Log(x)))
+}
+
+// Exp returns e**x
+func Exp(x Dual) Dual {
+    e := math.Exp(x.Val)
+    return x.chain(e, e, e)
+}
+
+// Log returns the natural logarithm of x
+func Log(x Dual) Dual {
+    return x.chain(math.Log(x.Val), 1.0/x.Val, -1.0/(x.Val*x.Val))
+}
+
+// Sin returns the sine of x
+func Sin(x Dual) Dual {
+    s, c := math.Sincos(x.Val)
+    return x.chain(s, c, -s)
+}
+
+// Cos returns the cosine of x
+func Cos(x Dual) Dual {
+    s, c := math.Sincos(x.Val)
+    return x.chain(c, -s, -c)
+}
+
+// Tan returns the tangent of x
+func Tan(x Dual) Dual {
+    t := math.Tan(x.Val)
+    sec2 := 1.0 + t*t
+    return x.chain(t, sec2, 2.0*t*sec2)
+}
+
+// Asin returns the arcsine of x
+func Asin(x Dual) Dual {
+    q := 1.0 - x.Val*x.Val
+    return x.chain(math.Asin(x.Val), 1.0/math.Sqrt(q), x.Val/(q*math.Sqrt(q)))
+}
+
+// Acos returns the arccosine of x
+func Acos(x Dual) Dual {
+    q := 1.0 - x.Val*x.Val
+    return x.chain(math.Acos(x.Val), -1.0/math.Sqrt(q), -x.Val/(q*math.Sqrt(q)))
+}
+
+// Atan returns the arctangent of x
+func Atan(x Dual) Dual {
+    q := 1.0 + x.Val*x.Val
+    return x.chain(math.Atan(x.Val), 1.0/q, -2.0*x.Val/(q*q))
+}
+
+// Sinh returns the hyperbolic sine of x
+func Sinh(x Dual) Dual {
+    s, c := math.Sinh(x.Val), math.Cosh(x.Val)
+    return x.chain(s, c, s)
+}
+
+// Cosh returns the hyperbolic cosine of x
+func Cosh(x Dual) Dual {
+    s, c := math.Sinh(x.Val), math.Cosh(x.Val)
+    return x.chain(c, s, c)
+}
+
+// Tanh returns the hyperbolic tangent of x
+func Tanh(x Dual) Dual {
+    t := math.Tanh(x.Val)
+    sech2 := 1.0 - t*t
+    return x.chain(t, sech2, -2.0*t*sech2)
+}
+
+// Abs returns the absolute value of x, the derivative at 0 is taken as 0
+func Abs(x Dual) Dual {
+    if x.Val < 0.0 {
+        return x.Neg()
+    }
+    if x.Val == 0.0 {
+        return Const(0.0)
+    }
+    return x
+}
+
+// Eval evaluates f and its first two derivatives at x
+// input:
+// the function written in dual numbers (f), the point to evaluate at (x)
+// output:
+// the function value (fx), first derivative (dfx), second derivative (d2fx)
+func Eval(f func(Dual) Dual, x float64) (fx float64, dfx float64, d2fx float64) {
+    y := f(Var(x))
+    return y.Val, y.D1, y.D2
+}
+
+// Differentiate
+// Builds the (f, df) pair expected by derivative based root methods such as rootmethods.Newtraph
+// input:
+// the function written in dual numbers (f)
+// output:
+// the function (fx), the exact derivative (dfx)
+func Differentiate(f func(Dual) Dual) (fx func(float64) float64, dfx func(float64) float64) {
+    fx = func(x float64) float64 {
+        return f(Const(x)).Val
+    }
+    dfx = func(x float64) float64 {
+        return f(Var(x)).D1
+    }
+    return fx, dfx
+}
+
+// Differentiate2
+// Builds the function with its first and second derivatives, the pair (df, d2f) can be passed to Newtraph to locate
+// the stationary points of f
+// input:
+// the function written in dual numbers (f)
+// output:
+// the function (fx), the exact first derivative (dfx), the exact second derivative (d2fx)
+func Differentiate2(f func(Dual) Dual) (fx func(float64) float64, dfx func(float64) float64, d2fx func(float64) float64) {
+    fx, dfx = Differentiate(f)
+    d2fx = func(x float64) float64 {
+        return f(Var(x)).D2
+    }
+    return fx, dfx, d2fx
+}
@@ -0,0 +1,87 @@
+package dual
+
+import (
+    "testing"
+    "fmt"
+    "math"
+    "example.com/rootmethods"
+)
+
+// TestEval calls dual.Eval with a function built from several elementary functions, checking
+// the value and the first two derivatives against the analytic results.
+func TestEval(t *testing.T) {
+    x := 0.7
+    es := 1e-12
+    f := func(x Dual) Dual {
+        return Sin(x).Mul(Exp(x)).Add(Log(x).Div(Pow(x, 2.0)))
+    }
+    fxwant := math.Sin(x)*math.Exp(x) + math.Log(x)/(x*x)
+    dfxwant := math.Exp(x)*(math.Sin(x) + math.Cos(x)) + (1.0 - 2.0*math.Log(x))/(x*x*x)
+    d2fxwant := 2.0*math.Exp(x)*math.Cos(x) + (6.0*math.Log(x) - 5.0)/(x*x*x*x)
+    fx, dfx, d2fx := Eval(f, x)
+    msg := fmt.Sprintf("%f, %f, %f", fx, dfx, d2fx)
+    want := fmt.Sprintf("%f, %f, %f", fxwant, dfxwant, d2fxwant)
+    if math.Abs(fx - fxwant) > es || math.Abs(dfx - dfxwant) > es || math.Abs(d2fx - d2fxwant) > es {
+        t.Fatalf(`Eval(f: x->sin(x)exp(x)+log(x)/x^2, 0.7) = %q, want %q`, msg, want)
+    }
+}
+
+// TestDifferentiate calls dual.Differentiate and dual.Differentiate2 with a function, checking
+// the returned functions against the analytic derivatives.
+func TestDifferentiate(t *testing.T) {
+    es := 1e-12
+    g := func(x Dual) Dual {
+        return Sqrt(x.Mul(x).Shift(1.0)).Sub(Atan(x).Scale(3.0))
+    }
+    f, df, d2f := Differentiate2(g)
+    for _, x := range []float64{-2.0, -0.5, 0.0, 1.5, 3.0} {
+        q := x*x + 1.0
+        fxwant := math.Sqrt(q) - 3.0*math.Atan(x)
+        dfxwant := x/math.Sqrt(q) - 3.0/q
+        d2fxwant := 1.0/(q*math.Sqrt(q)) + 6.0*x/(q*q)
+        if math.Abs(f(x) - fxwant) > es || math.Abs(df(x) - dfxwant) > es || math.Abs(d2f(x) - d2fxwant) > es {
+            t.Fatalf(`Differentiate2(g)(%f) = %f, %f, %f, want %f, %f, %f`, x, f(x), df(x), d2f(x), fxwant, dfxwant, d2fxwant)
+        }
+    }
+}
+
+// TestDifferentiateNewtraph passes the derivatives from dual.Differentiate and dual.Differentiate2 to
+// rootmethods.Newtraph, checking for the root of cos(x) - x and the minimum of x^2/10 - 2 sin(x).
+func TestDifferentiateNewtraph(t *testing.T) {
+    es := 1e-10
+    f, df := Differentiate(func(x Dual) Dual {
+        return Cos(x).Sub(x)
+    })
+    root, fx, ea, iter, err := rootmethods.Newtraph(f, df, 1.0, es, 50)
+    rootwant := 0.7390851332151607
+    if err != nil || math.Abs(root - rootwant) > 1e-12 || ea > es {
+        t.Fatalf(`Newtraph(Differentiate(f: x->cos(x)-x), 1, 1e-10, 50) = %f, %g, %g, %d, %v, want %f`, root, fx, ea, iter, err, rootwant)
+    }
+    _, dg, d2g := Differentiate2(func(x Dual) Dual {
+        return Pow(x, 2.0).Scale(0.1).Sub(Sin(x).Scale(2.0))
+    })
+    xmin, _, ea, iter, err := rootmethods.Newtraph(dg, d2g, 1.0, es, 50)
+    xminwant := 1.4275517787645942
+    if err != nil || math.Abs(xmin - xminwant) > 1e-12 || ea > es {
+        t.Fatalf(`Newtraph(Differentiate2(g: x->x^2/10-2sin(x)), 1, 1e-10, 50) = %f, %g, %d, %v, want %f`, xmin, ea, iter, err, xminwant)
+    }
+}
+
+// TestPowZero calls dual.Pow with the exponents 0, 1, 2 and 3 at x = 0, checking for finite exact derivatives.
+func TestPowZero(t *testing.T) {
+    for _, p := range []float64{0.0, 1.0, 2.0, 3.0} {
+        fx, dfx, d2fx := Eval(func(x Dual) Dual { return Pow(x, p) }, 0.0)
+        want := []float64{0.0, 0.0, 0.0}
+        switch p {
+        case 0.0:
+            want = []float64{1.0, 0.0, 0.0}
+        case 1.0:
+            want = []float64{0.0, 1.0, 0.0}
+        case 2.0:
+            want = []float64{0.0, 0.0, 2.0}
+        }
+        if fx != want[0] || dfx != want[1] || d2fx != want[2] {
+            t.Fatalf(`Eval(f: x->x^%g, 0) = %f, %f, %f, want %v`, p, fx, dfx, d2fx, want)
+        }
+    }
+}
@@ -0,0 +1,7 @@
+module example.com/dual
+
+go 1.15
+
+replace example.com/rootmethods => ../rootmethods
+
+require example.com/rootmethods v0.0.0-00010101000000-000000000000
@@ -0,0 +1,3 @@
+module example.com/linprog
+
+go 1.15
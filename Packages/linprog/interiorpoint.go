@@ -0,0 +1,205 @@
+package linprog
+
+import (
+    "errors"
+    "math"
+)
+
+// stepLength returns the largest alpha <= 1 with v + alpha*dv >= 0
+func stepLength(v []float64, dv []float64) float64 {
+    alpha := 1.0
+    for i := range v {
+        if dv[i] < 0.0 {
+            alpha = math.Min(alpha, -v[i]/dv[i])
+        }
+    }
+    return alpha
+}
+
+// InteriorPoint (Primal-dual interior point method)
+// Solves min c'x subject to A x <= b, Aeq x = beq and x >= 0 with Mehrotra's predictor-corrector method applied to the
+// homogeneous self-dual embedding, which needs no feasible starting point and detects infeasibility and unboundedness
+// from the embedding variable tau going to zero. The duals and reduced costs follow the conventions of Simplex.
+// input:
+// the cost vector (c), the inequality matrix (A, nil for none), its right hand side (b), the equality matrix (Aeq, nil for none), its right hand side (beq), relative residual tolerance (es), maximum iterations (iter)
+// output:
+// the optimal x (x), objective value (fx), duals of the rows of A and Aeq (y), reduced costs (d), largest relative residual of primal feasibility, dual feasibility and duality gap (ea), iterations done (iter)
+func InteriorPoint(c []float64, A [][]float64, b []float64, Aeq [][]float64, beq []float64, es float64, maxit int) (x []float64, fx float64, y []float64, d []float64, ea float64, iter int, err error) {
+    if es <= 0.0 {
+        return nil, 0.0, nil, nil, 0.0, 0, errors.New("es must be greater than 0")
+    }
+    s, err := standardForm(c, A, b, Aeq, beq)
+    if err != nil {
+        return nil, 0.0, nil, nil, 0.0, 0, err
+    }
+    m := len(s.b)
+    n := len(s.c)
+    xs := make([]float64, n)
+    z := make([]float64, n)
+    for j := range xs {
+        xs[j], z[j] = 1.0, 1.0
+    }
+    ys := make([]float64, m)
+    tau, kappa := 1.0, 1.0
+    // Residuals of A x = b tau, A'y + z = c tau and b'y - c'x = kappa
+    Ax := func(v []float64) []float64 {
+        r := make([]float64, m)
+        for i := range r {
+            r[i] = dot(s.A[i], v)
+        }
+        return r
+    }
+    ATy := func(v []float64) []float64 {
+        r := make([]float64, n)
+        for i := range v {
+            for j := range r {
+                r[j] += s.A[i][j]*v[i]
+            }
+        }
+        return r
+    }
+    residuals := func() (rp []float64, rd []float64, rg float64) {
+        rp = Ax(xs)
+        for i := range rp {
+            rp[i] = tau*s.b[i] - rp[i]
+        }
+        rd = ATy(ys)
+        for j := range rd {
+            rd[j] = tau*s.c[j] - rd[j] - z[j]
+        }
+        rg = dot(s.c, xs) - dot(s.b, ys) + kappa
+        return rp, rd, rg
+    }
+    rp0, rd0, rg0 := residuals()
+    np0 := math.Max(1.0, norminf(rp0))
+    nd0 := math.Max(1.0, norminf(rd0))
+    ng0 := math.Max(1.0, math.Abs(rg0))
+    mu0 := (dot(xs, z) + tau*kappa)/float64(n + 1)
+    iter = 0
+    for ; ; iter ++ {
+        rp, rd, rg := residuals()
+        mu := (dot(xs, z) + tau*kappa)/float64(n + 1)
+        cx, by := dot(s.c, xs), dot(s.b, ys)
+        rhop := norminf(rp)/np0
+        rhod := norminf(rd)/nd0
+        rhoa := math.Abs(cx - by)/(tau + math.Abs(by))
+        rhog := math.Abs(rg)/ng0
+        ea = math.Max(rhop, math.Max(rhod, rhoa))
+        if ea <= es {
+            break
+        }
+        if (rhop <= es && rhod <= es && rhog <= es && tau <= es*math.Max(1.0, kappa)) || (mu/mu0 <= es && tau <= es*math.Min(1.0, kappa)) {
+            if by > 0.0 { // y is a Farkas certificate, A'y <= 0 with b'y > 0
+                return nil, 0.0, nil, nil, ea, iter, ErrInfeasible
+            }
+            return nil, 0.0, nil, nil, ea, iter, ErrUnbounded
+        }
+        if iter >= maxit {
+            break
+        }
+        // Normal equations matrix A D A' with D = X/Z, shared by the predictor and the corrector
+        D := make([]float64, n)
+        for j := range D {
+            D[j] = xs[j]/z[j]
+        }
+        M := make([][]float64, m)
+        for i := range M {
+            M[i] = make([]float64, m)
+        }
+        for i := 0; i < m; i++ {
+            for k := 0; k <= i; k++ {
+                v := 0.0
+                for j := 0; j < n; j++ {
+                    v += s.A[i][j]*D[j]*s.A[k][j]
+                }
+                M[i][k], M[k][i] = v, v
+            }
+        }
+        L := cholesky(M)
+        Dc := make([]float64, n)
+        for j := range Dc {
+            Dc[j] = D[j]*s.c[j]
+        }
+        qrhs := Ax(Dc)
+        for i := range qrhs {
+            qrhs[i] += s.b[i]
+        }
+        q := choleskySolve(L, qrhs)
+        v := ATy(q)
+        for j := range v {
+            v[j] = D[j]*(v[j] - s.c[j])
+        }
+        // direction solves the Newton system for the complementarity targets rxs and rtk, reducing the residuals by eta
+        direction := func(rxs []float64, rtk float64, eta float64) (dx []float64, dy []float64, dz []float64, dtau float64, dkappa float64) {
+            t1 := make([]float64, n)
+            for j := range t1 {
+                t1[j] = D[j]*(eta*rd[j] - rxs[j]/xs[j])
+            }
+            prhs := Ax(t1)
+            for i := range prhs {
+                prhs[i] += eta*rp[i]
+            }
+            p := choleskySolve(L, prhs)
+            u := ATy(p)
+            for j := range u {
+                u[j] = D[j]*u[j] - t1[j]
+            }
+            dtau = (eta*rg - dot(s.b, p) + dot(s.c, u) + rtk/tau)/(dot(s.b, q) - dot(s.c, v) + kappa/tau)
+            dy = make([]float64, m)
+            for i := range dy {
+                dy[i] = p[i] + q[i]*dtau
+            }
+            dx = make([]float64, n)
+            dz = make([]float64, n)
+            for j := range dx {
+                dx[j] = u[j] + v[j]*dtau
+                dz[j] = (rxs[j] - z[j]*dx[j])/xs[j]
+            }
+            dkappa = (rtk - kappa*dtau)/tau
+            return dx, dy, dz, dtau, dkappa
+        }
+        maxstep := func(dx []float64, dz []float64, dtau float64, dkappa float64) float64 {
+            alpha := math.Min(stepLength(xs, dx), stepLength(z, dz))
+            return math.Min(alpha, stepLength([]float64{tau, kappa}, []float64{dtau, dkappa}))
+        }
+        // Predictor, the affine scaling direction
+        rxs := make([]float64, n)
+        for j := range rxs {
+            rxs[j] = -xs[j]*z[j]
+        }
+        dxa, _, dza, dtaua, dkappaa := direction(rxs, -tau*kappa, 1.0)
+        alpha := maxstep(dxa, dza, dtaua, dkappaa)
+        mua := (tau + alpha*dtaua)*(kappa + alpha*dkappaa)
+        for j := range xs {
+            mua += (xs[j] + alpha*dxa[j])*(z[j] + alpha*dza[j])
+        }
+        mua /= float64(n + 1)
+        sigma := math.Pow(mua/mu, 3.0)
+        // Corrector, centering and second order terms
+        for j := range rxs {
+            rxs[j] = sigma*mu - xs[j]*z[j] - dxa[j]*dza[j]
+        }
+        dx, dy, dz, dtau, dkappa := direction(rxs, sigma*mu - tau*kappa - dtaua*dkappaa, 1.0 - sigma)
+        alpha = math.Min(1.0, 0.99*maxstep(dx, dz, dtau, dkappa))
+        for j := range xs {
+            xs[j] += alpha*dx[j]
+            z[j] += alpha*dz[j]
+        }
+        for i := range ys {
+            ys[i] += alpha*dy[i]
+        }
+        tau += alpha*dtau
+        kappa += alpha*dkappa
+    }
+    x = make([]float64, s.n)
+    d = make([]float64, s.n)
+    for j := range x {
+        x[j] = xs[j]/tau
+        d[j] = z[j]/tau
+    }
+    y = make([]float64, m)
+    for i := range y {
+        y[i] = s.sign[i]*ys[i]/tau
+    }
+    return x, dot(c, x), y, d, ea, iter, nil
+}
@@ -0,0 +1,72 @@
+package linprog
+
+import (
+    "testing"
+    "fmt"
+    "math"
+)
+
+// TestInteriorPoint calls linprog.InteriorPoint with the product mix problem max 3 x0 + 5 x1 subject to x0 <= 4,
+// 2 x1 <= 12 and 3 x0 + 2 x1 <= 18, checking for the optimum, the duals and the reduced costs.
+func TestInteriorPoint(t *testing.T) {
+    c := []float64{-3.0, -5.0}
+    A := [][]float64{{1.0, 0.0}, {0.0, 2.0}, {3.0, 2.0}}
+    b := []float64{4.0, 12.0, 18.0}
+    es := 1e-9
+    maxit := 100
+    x, fx, y, d, ea, iter, err := InteriorPoint(c, A, b, nil, nil, es, maxit)
+    msg := fmt.Sprintf("%v, %f, %v, %v, %g, %d", x, fx, y, d, ea, iter)
+    want := fmt.Sprintf("[2 6], -36, [0 -1.5 -1], [0 0], %g, %d < %d", es, iter, maxit)
+    if err != nil || !approxEqual(x, []float64{2.0, 6.0}, 1e-6) || math.Abs(fx + 36.0) > 1e-6 || !approxEqual(y, []float64{0.0, -1.5, -1.0}, 1e-6) || !approxEqual(d, []float64{0.0, 0.0}, 1e-6) || ea > es || iter >= maxit {
+        t.Fatalf(`InteriorPoint(c, A, b, nil, nil, 1e-9, 100) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestInteriorPointMixed calls linprog.InteriorPoint with min 2 x0 + 3 x1 subject to -x0 - x1 <= -4, x0 - x1 = 1
+// and the redundant 2 x0 - 2 x1 = 2, checking for the optimum.
+func TestInteriorPointMixed(t *testing.T) {
+    c := []float64{2.0, 3.0}
+    A := [][]float64{{-1.0, -1.0}}
+    b := []float64{-4.0}
+    Aeq := [][]float64{{1.0, -1.0}, {2.0, -2.0}}
+    beq := []float64{1.0, 2.0}
+    x, fx, y, d, ea, iter, err := InteriorPoint(c, A, b, Aeq, beq, 1e-9, 100)
+    msg := fmt.Sprintf("%v, %f, %v, %v, %g, %d", x, fx, y, d, ea, iter)
+    want := "[2.5 1.5], 9.5, [-2.5 ...], [0 0]"
+    if err != nil || !approxEqual(x, []float64{2.5, 1.5}, 1e-6) || math.Abs(fx - 9.5) > 1e-6 || math.Abs(y[0] + 2.5) > 1e-6 || !approxEqual(d, []float64{0.0, 0.0}, 1e-6) {
+        t.Fatalf(`InteriorPoint(c, A, b, Aeq, beq, 1e-9, 100) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestInteriorPointInfeasible calls linprog.InteriorPoint with x0 + x1 <= 1 and x0 + x1 >= 2,
+// checking for ErrInfeasible.
+func TestInteriorPointInfeasible(t *testing.T) {
+    c := []float64{1.0, 1.0}
+    A := [][]float64{{1.0, 1.0}, {-1.0, -1.0}}
+    b := []float64{1.0, -2.0}
+    x, fx, y, d, ea, iter, err := InteriorPoint(c, A, b, nil, nil, 1e-9, 100)
+    if x != nil || fx != 0.0 || y != nil || d != nil || err != ErrInfeasible {
+        t.Fatalf(`InteriorPoint(c, A, b, nil, nil, 1e-9, 100) = %v, %f, %v, %v, %g, %d, %v, want nil, 0, nil, nil, ea, iter, ErrInfeasible`, x, fx, y, d, ea, iter, err)
+    }
+}
+
+// TestInteriorPointUnbounded calls linprog.InteriorPoint with min -x0 subject to x0 - x1 <= 1,
+// checking for ErrUnbounded.
+func TestInteriorPointUnbounded(t *testing.T) {
+    c := []float64{-1.0, 0.0}
+    A := [][]float64{{1.0, -1.0}}
+    b := []float64{1.0}
+    x, fx, y, d, ea, iter, err := InteriorPoint(c, A, b, nil, nil, 1e-9, 100)
+    if x != nil || fx != 0.0 || y != nil || d != nil || err != ErrUnbounded {
+        t.Fatalf(`InteriorPoint(c, A, b, nil, nil, 1e-9, 100) = %v, %f, %v, %v, %g, %d, %v, want nil, 0, nil, nil, ea, iter, ErrUnbounded`, x, fx, y, d, ea, iter, err)
+    }
+}
+
+// TestInteriorPointEs calls linprog.InteriorPoint with a nonpositive tolerance,
+// checking for an error.
+func TestInteriorPointEs(t *testing.T) {
+    x, fx, y, d, ea, iter, err := InteriorPoint([]float64{1.0}, nil, nil, nil, nil, 0.0, 100)
+    if x != nil || fx != 0.0 || y != nil || d != nil || ea != 0.0 || iter != 0 || err == nil {
+        t.Fatalf(`InteriorPoint([1], nil, nil, nil, nil, 0, 100) = %v, %f, %v, %v, %g, %d, %v, want nil, 0, nil, nil, 0, 0, error`, x, fx, y, d, ea, iter, err)
+    }
+}
@@ -0,0 +1,181 @@
+package linprog
+
+import (
+    "errors"
+    "math"
+)
+
+// ErrInfeasible is returned when the constraints of a linear program can not be satisfied
+var ErrInfeasible = errors.New("The problem is infeasible")
+
+// ErrUnbounded is returned when the objective of a linear program decreases without bound on the feasible set
+var ErrUnbounded = errors.New("The problem is unbounded")
+
+// standard holds a linear program in the standard form min c'x subject to A x = b, x >= 0 and b >= 0, with one slack
+// column per inequality row after the original variables. The rows with a negative right hand side are negated,
+// sign records this so the duals can be mapped back to the original rows.
+type standard struct {
+    c    []float64
+    A    [][]float64
+    b    []float64
+    sign []float64
+    n    int
+    mi   int
+}
+
+// standardForm converts min c'x subject to A x <= b, Aeq x = beq, x >= 0 into the standard form
+func standardForm(c []float64, A [][]float64, b []float64, Aeq [][]float64, beq []float64) (*standard, error) {
+    n := len(c)
+    if n == 0 {
+        return nil, errors.New("c must not be empty")
+    }
+    if len(A) != len(b) {
+        return nil, errors.New("A and b must have the same number of rows")
+    }
+    if len(Aeq) != len(beq) {
+        return nil, errors.New("Aeq and beq must have the same number of rows")
+    }
+    mi := len(A)
+    m := mi + len(Aeq)
+    s := &standard{make([]float64, n + mi), make([][]float64, m), make([]float64, m), make([]float64, m), n, mi}
+    copy(s.c, c)
+    for i := 0; i < m; i++ {
+        var row []float64
+        var rhs float64
+        if i < mi {
+            row, rhs = A[i], b[i]
+        } else {
+            row, rhs = Aeq[i-mi], beq[i-mi]
+        }
+        if len(row) != n {
+            return nil, errors.New("Every row of A and Aeq must have one entry per variable")
+        }
+        s.A[i] = make([]float64, n + mi)
+        copy(s.A[i], row)
+        if i < mi {
+            s.A[i][n+i] = 1.0
+        }
+        s.b[i] = rhs
+        s.sign[i] = 1.0
+        if rhs < 0.0 {
+            for j := range s.A[i] {
+                s.A[i][j] = -s.A[i][j]
+            }
+            s.b[i] = -rhs
+            s.sign[i] = -1.0
+        }
+    }
+    return s, nil
+}
+
+// dot returns the inner product of x and y
+func dot(x []float64, y []float64) float64 {
+    s := 0.0
+    for i := range x {
+        s += x[i]*y[i]
+    }
+    return s
+}
+
+// norminf returns the largest absolute entry of x
+func norminf(x []float64) float64 {
+    m := 0.0
+    for _, v := range x {
+        m = math.Max(m, math.Abs(v))
+    }
+    return m
+}
+
+// invert returns the inverse of the square matrix B by Gauss-Jordan elimination with partial pivoting, ok is false
+// when B is singular
+func invert(B [][]float64) (Binv [][]float64, ok bool) {
+    m := len(B)
+    W := make([][]float64, m)
+    Binv = make([][]float64, m)
+    for i := range B {
+        W[i] = append([]float64(nil), B[i]...)
+        Binv[i] = make([]float64, m)
+        Binv[i][i] = 1.0
+    }
+    for k := 0; k < m; k++ {
+        p := k
+        for i := k + 1; i < m; i++ {
+            if math.Abs(W[i][k]) > math.Abs(W[p][k]) {
+                p = i
+            }
+        }
+        if W[p][k] == 0.0 {
+            return nil, false
+        }
+        W[k], W[p] = W[p], W[k]
+        Binv[k], Binv[p] = Binv[p], Binv[k]
+        pivot := W[k][k]
+        for j := 0; j < m; j++ {
+            W[k][j] /= pivot
+            Binv[k][j] /= pivot
+        }
+        for i := 0; i < m; i++ {
+            if i != k && W[i][k] != 0.0 {
+                factor := W[i][k]
+                for j := 0; j < m; j++ {
+                    W[i][j] -= factor*W[k][j]
+                    Binv[i][j] -= factor*Binv[k][j]
+                }
+            }
+        }
+    }
+    return Binv, true
+}
+
+// cholesky returns the lower triangular factor of the symmetric positive semidefinite matrix M, pivots that are
+// negligible compared to the diagonal belong to linearly dependent rows and are replaced by a huge value which
+// zeroes the corresponding component of the solution
+func cholesky(M [][]float64) [][]float64 {
+    n := len(M)
+    dmax := 0.0
+    for i := range M {
+        dmax = math.Max(dmax, M[i][i])
+    }
+    L := make([][]float64, n)
+    for i := range L {
+        L[i] = make([]float64, n)
+    }
+    for j := 0; j < n; j++ {
+        d := M[j][j]
+        for k := 0; k < j; k++ {
+            d -= L[j][k]*L[j][k]
+        }
+        if d <= 1e-14*dmax {
+            L[j][j] = 1e64
+        } else {
+            L[j][j] = math.Sqrt(d)
+        }
+        for i := j + 1; i < n; i++ {
+            s := M[i][j]
+            for k := 0; k < j; k++ {
+                s -= L[i][k]*L[j][k]
+            }
+            L[i][j] = s/L[j][j]
+        }
+    }
+    return L
+}
+
+// choleskySolve solves L L' x = b
+func choleskySolve(L [][]float64, b []float64) []float64 {
+    n := len(b)
+    x := append([]float64(nil), b...)
+    for i := 0; i < n; i++ {
+        for k := 0; k < i; k++ {
+            x[i] -= L[i][k]*x[k]
+        }
+        x[i] /= L[i][i]
+    }
+    for i := n - 1; i >= 0; i-- {
+        for k := i + 1; k < n; k++ {
+            x[i] -= L[k][i]*x[k]
+        }
+        x[i] /= L[i][i]
+    }
+    return x
+}
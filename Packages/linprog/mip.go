@@ -0,0 +1,260 @@
+package linprog
+
+import (
+    "container/heap"
+    "errors"
+    "math"
+    "sync"
+)
+
+// VarType is the type of a variable in a mixed integer program
+type VarType int
+
+const (
+    // Continuous variables take any nonnegative value
+    Continuous VarType = iota
+    // Integer variables take nonnegative integer values
+    Integer
+    // Binary variables take the values 0 and 1
+    Binary
+)
+
+// NodeSelection is the rule choosing the next open node in branch and bound
+type NodeSelection int
+
+const (
+    // BestBound explores the node with the lowest LP bound first, which raises the global bound fastest
+    BestBound NodeSelection = iota
+    // DepthFirst explores the most recently created node first, which finds integer solutions early and keeps few nodes open
+    DepthFirst
+)
+
+// integerTol is the distance from an integer below which a value counts as integral
+const integerTol = 1e-6
+
+// node is a subproblem of branch and bound, the LP relaxation with tightened variable bounds
+type node struct {
+    lower []float64
+    upper []float64
+    bound float64
+    depth int
+    order int
+}
+
+// nodeQueue holds the open nodes ordered by the node selection rule
+type nodeQueue struct {
+    nodes     []*node
+    selection NodeSelection
+}
+
+func (q *nodeQueue) Len() int { return len(q.nodes) }
+func (q *nodeQueue) Less(i, j int) bool {
+    if q.selection == DepthFirst {
+        return q.nodes[i].order > q.nodes[j].order
+    }
+    if q.nodes[i].bound != q.nodes[j].bound {
+        return q.nodes[i].bound < q.nodes[j].bound
+    }
+    return q.nodes[i].order > q.nodes[j].order
+}
+func (q *nodeQueue) Swap(i, j int) { q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i] }
+func (q *nodeQueue) Push(x interface{}) { q.nodes = append(q.nodes, x.(*node)) }
+func (q *nodeQueue) Pop() interface{} {
+    n := len(q.nodes)
+    x := q.nodes[n-1]
+    q.nodes = q.nodes[:n-1]
+    return x
+}
+
+// relaxation solves the LP relaxation of a node, the variable bounds are appended as inequality rows
+func relaxation(c []float64, A [][]float64, b []float64, Aeq [][]float64, beq []float64, nd *node) ([]float64, float64, error) {
+    n := len(c)
+    rows := append([][]float64(nil), A...)
+    rhs := append([]float64(nil), b...)
+    for j := 0; j < n; j++ {
+        if !math.IsInf(nd.upper[j], 1) {
+            row := make([]float64, n)
+            row[j] = 1.0
+            rows = append(rows, row)
+            rhs = append(rhs, nd.upper[j])
+        }
+        if nd.lower[j] > 0.0 {
+            row := make([]float64, n)
+            row[j] = -1.0
+            rows = append(rows, row)
+            rhs = append(rhs, -nd.lower[j])
+        }
+    }
+    x, fx, _, _, _, err := Simplex(c, rows, rhs, Aeq, beq, 50*(n + len(rows) + len(Aeq)) + 100)
+    return x, fx, err
+}
+
+// BranchAndBound (LP based branch and bound for mixed integer linear programs)
+// Solves min c'x subject to A x <= b, Aeq x = beq and x >= 0 with the variables of type Integer or Binary restricted to
+// integers. Every node solves its LP relaxation with Simplex and branches on the most fractional variable. The search
+// stops when the relative gap (fx - bound)/max(1, |fx|) between the incumbent and the lowest open bound is at most gap,
+// when the incumbent callback returns true or after maxnodes nodes. With workers > 1 nodes are solved concurrently.
+// Nodes with an infeasible relaxation are pruned, any other LP error stops the search and is returned with the incumbent.
+// input:
+// the cost vector (c), the inequality matrix (A, nil for none), its right hand side (b), the equality matrix (Aeq, nil for none), its right hand side (beq), variable types (types, nil for all Integer), node selection rule (selection), function called with every new incumbent (incumbent, nil for none), relative gap tolerance (gap), number of goroutines solving nodes (workers), maximum nodes (maxnodes)
+// output:
+// the best integer solution found (x), its objective value (fx), lower bound on the optimal value (bound), nodes solved (nodes)
+func BranchAndBound(c []float64, A [][]float64, b []float64, Aeq [][]float64, beq []float64, types []VarType, selection NodeSelection, incumbent func(x []float64, fx float64) bool, gap float64, workers int, maxnodes int) (x []float64, fx float64, bound float64, nodes int, err error) {
+    n := len(c)
+    if types != nil && len(types) != n {
+        return nil, 0.0, 0.0, 0, errors.New("types must have one entry per variable")
+    }
+    if gap < 0.0 {
+        return nil, 0.0, 0.0, 0, errors.New("gap must be greater than or equal to 0")
+    }
+    if maxnodes < 1 {
+        return nil, 0.0, 0.0, 0, errors.New("maxnodes must be greater than 0")
+    }
+    if workers < 1 {
+        workers = 1
+    }
+    if types == nil {
+        types = make([]VarType, n)
+        for j := range types {
+            types[j] = Integer
+        }
+    }
+    root := &node{make([]float64, n), make([]float64, n), math.Inf(-1), 0, 0}
+    for j := range root.upper {
+        root.upper[j] = math.Inf(1)
+        if types[j] == Binary {
+            root.upper[j] = 1.0
+        }
+    }
+    // The root relaxation decides infeasibility and unboundedness and checks the arguments
+    rx, rfx, err := relaxation(c, A, b, Aeq, beq, root)
+    if err != nil {
+        return nil, 0.0, 0.0, 1, err
+    }
+    root.bound = rfx
+    fx = math.Inf(1)
+    var mu sync.Mutex
+    cond := sync.NewCond(&mu)
+    queue := &nodeQueue{nil, selection}
+    running := make(map[*node]bool)
+    order := 0
+    stopped := false
+    var failure error // an LP error other than infeasibility, which stops the search
+    failbound := math.Inf(1)
+    nodes = 1
+    closed := func(bound float64) bool { // the node can not improve the incumbent by more than the gap
+        return bound >= fx - gap*math.Max(1.0, math.Abs(fx))
+    }
+    lowest := func() float64 {
+        low := fx
+        for _, nd := range queue.nodes {
+            low = math.Min(low, nd.bound)
+        }
+        for nd := range running {
+            low = math.Min(low, nd.bound)
+        }
+        return low
+    }
+    // process prunes or branches a solved node, it is called with the lock held
+    process := func(nd *node, xr []float64, fr float64) {
+        if closed(fr) {
+            return
+        }
+        branch := -1
+        frac := integerTol
+        for j := range xr {
+            if types[j] != Continuous {
+                if f := math.Abs(xr[j] - math.Round(xr[j])); f > frac {
+                    branch, frac = j, f
+                }
+            }
+        }
+        if branch < 0 { // integral, a new incumbent
+            x = append([]float64(nil), xr...)
+            for j := range x {
+                if types[j] != Continuous {
+                    x[j] = math.Round(x[j])
+                }
+            }
+            fx = fr
+            if incumbent != nil && incumbent(append([]float64(nil), x...), fx) {
+                stopped = true
+            }
+            if closed(lowest()) {
+                stopped = true
+            }
+            return
+        }
+        down := &node{append([]float64(nil), nd.lower...), append([]float64(nil), nd.upper...), fr, nd.depth + 1, 0}
+        up := &node{append([]float64(nil), nd.lower...), append([]float64(nil), nd.upper...), fr, nd.depth + 1, 0}
+        down.upper[branch] = math.Floor(xr[branch])
+        up.lower[branch] = math.Ceil(xr[branch])
+        // The child on the side the LP value is rounded to gets the later order and is explored first depth first
+        first, second := down, up
+        if xr[branch] - math.Floor(xr[branch]) < 0.5 {
+            first, second = up, down
+        }
+        order++
+        first.order = order
+        heap.Push(queue, first)
+        order++
+        second.order = order
+        heap.Push(queue, second)
+    }
+    process(root, rx, rfx)
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            mu.Lock()
+            defer mu.Unlock()
+            for {
+                for !stopped && queue.Len() == 0 && len(running) > 0 {
+                    cond.Wait()
+                }
+                if stopped || queue.Len() == 0 {
+                    cond.Broadcast()
+                    return
+                }
+                if nodes >= maxnodes {
+                    stopped = true
+                    cond.Broadcast()
+                    return
+                }
+                nd := heap.Pop(queue).(*node)
+                if closed(nd.bound) {
+                    continue
+                }
+                nodes++
+                running[nd] = true
+                mu.Unlock()
+                xr, fr, lperr := relaxation(c, A, b, Aeq, beq, nd)
+                mu.Lock()
+                delete(running, nd)
+                if lperr == nil {
+                    process(nd, xr, fr)
+                } else if lperr != ErrInfeasible && failure == nil { // an infeasible node is pruned
+                    failure, failbound = lperr, nd.bound
+                    stopped = true
+                }
+                cond.Broadcast()
+            }
+        }()
+    }
+    wg.Wait()
+    bound = math.Min(lowest(), failbound)
+    if failure != nil {
+        if x == nil {
+            return nil, 0.0, bound, nodes, failure
+        }
+        return x, fx, bound, nodes, failure
+    }
+    if x == nil {
+        if stopped {
+            return nil, 0.0, bound, nodes, errors.New("No integer solution found within the node limit")
+        }
+        return nil, 0.0, bound, nodes, ErrInfeasible
+    }
+    return x, fx, bound, nodes, nil
+}
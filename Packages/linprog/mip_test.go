@@ -0,0 +1,110 @@
+package linprog
+
+import (
+    "testing"
+    "fmt"
+    "math"
+)
+
+// TestBranchAndBound calls linprog.BranchAndBound with the binary knapsack max 10 x0 + 13 x1 + 7 x2 + 8 x3 subject to
+// 3 x0 + 4 x1 + 2 x2 + 3 x3 <= 7 for both node selection rules and with several workers, checking for the optimum
+// 23 at (1, 1, 0, 0).
+func TestBranchAndBound(t *testing.T) {
+    c := []float64{-10.0, -13.0, -7.0, -8.0}
+    A := [][]float64{{3.0, 4.0, 2.0, 3.0}}
+    b := []float64{7.0}
+    types := []VarType{Binary, Binary, Binary, Binary}
+    for _, selection := range []NodeSelection{BestBound, DepthFirst} {
+        for _, workers := range []int{1, 4} {
+            x, fx, bound, nodes, err := BranchAndBound(c, A, b, nil, nil, types, selection, nil, 0.0, workers, 1000)
+            msg := fmt.Sprintf("%v, %f, %f, %d", x, fx, bound, nodes)
+            want := "[1 1 0 0], -23, -23, nodes"
+            if err != nil || !approxEqual(x, []float64{1.0, 1.0, 0.0, 0.0}, 0.0) || math.Abs(fx + 23.0) > 1e-9 || math.Abs(bound + 23.0) > 1e-9 {
+                t.Fatalf(`BranchAndBound(c, A, b, nil, nil, types, %d, nil, 0, %d, 1000) = %q, %v, want match for %#v, nil`, selection, workers, msg, err, want)
+            }
+        }
+    }
+}
+
+// TestBranchAndBoundInteger calls linprog.BranchAndBound with max 5 x0 + 8 x1 subject to x0 + x1 <= 6 and
+// 5 x0 + 9 x1 <= 45 with integer x, checking for the optimum 40 at (0, 5).
+func TestBranchAndBoundInteger(t *testing.T) {
+    c := []float64{-5.0, -8.0}
+    A := [][]float64{{1.0, 1.0}, {5.0, 9.0}}
+    b := []float64{6.0, 45.0}
+    x, fx, bound, nodes, err := BranchAndBound(c, A, b, nil, nil, nil, BestBound, nil, 0.0, 1, 1000)
+    msg := fmt.Sprintf("%v, %f, %f, %d", x, fx, bound, nodes)
+    want := "[0 5], -40, -40, nodes"
+    if err != nil || !approxEqual(x, []float64{0.0, 5.0}, 0.0) || math.Abs(fx + 40.0) > 1e-9 || math.Abs(bound + 40.0) > 1e-9 {
+        t.Fatalf(`BranchAndBound(c, A, b, nil, nil, nil, BestBound, nil, 0, 1, 1000) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestBranchAndBoundMixed calls linprog.BranchAndBound with min -x0 - 2 x1 subject to x0 + x1 <= 3.5 and x1 <= 2.5
+// with continuous x0 and integer x1, checking for the optimum -5.5 at (1.5, 2).
+func TestBranchAndBoundMixed(t *testing.T) {
+    c := []float64{-1.0, -2.0}
+    A := [][]float64{{1.0, 1.0}, {0.0, 1.0}}
+    b := []float64{3.5, 2.5}
+    types := []VarType{Continuous, Integer}
+    x, fx, bound, nodes, err := BranchAndBound(c, A, b, nil, nil, types, DepthFirst, nil, 0.0, 1, 1000)
+    msg := fmt.Sprintf("%v, %f, %f, %d", x, fx, bound, nodes)
+    want := "[1.5 2], -5.5, -5.5, nodes"
+    if err != nil || !approxEqual(x, []float64{1.5, 2.0}, 1e-9) || math.Abs(fx + 5.5) > 1e-9 || math.Abs(bound + 5.5) > 1e-9 {
+        t.Fatalf(`BranchAndBound(c, A, b, nil, nil, types, DepthFirst, nil, 0, 1, 1000) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestBranchAndBoundInfeasible calls linprog.BranchAndBound with 2 x0 = 1 and integer x0,
+// checking for ErrInfeasible.
+func TestBranchAndBoundInfeasible(t *testing.T) {
+    Aeq := [][]float64{{2.0}}
+    beq := []float64{1.0}
+    x, fx, bound, nodes, err := BranchAndBound([]float64{1.0}, nil, nil, Aeq, beq, nil, BestBound, nil, 0.0, 1, 1000)
+    if x != nil || fx != 0.0 || err != ErrInfeasible {
+        t.Fatalf(`BranchAndBound([1], nil, nil, Aeq, beq, nil, BestBound, nil, 0, 1, 1000) = %v, %f, %f, %d, %v, want nil, 0, bound, nodes, ErrInfeasible`, x, fx, bound, nodes, err)
+    }
+}
+
+// TestBranchAndBoundMaxnodes calls linprog.BranchAndBound with maxnodes = 0,
+// checking for an error.
+func TestBranchAndBoundMaxnodes(t *testing.T) {
+    x, fx, bound, nodes, err := BranchAndBound([]float64{1.0}, nil, nil, nil, nil, nil, BestBound, nil, 0.0, 1, 0)
+    if x != nil || fx != 0.0 || bound != 0.0 || nodes != 0 || err == nil {
+        t.Fatalf(`BranchAndBound([1], nil, nil, nil, nil, nil, BestBound, nil, 0, 1, 0) = %v, %f, %f, %d, %v, want nil, 0, 0, 0, error`, x, fx, bound, nodes, err)
+    }
+}
+
+// TestBranchAndBoundIncumbent calls linprog.BranchAndBound with a binary knapsack problem and a callback stopping at
+// the first incumbent, checking that the callback saw the returned solution.
+func TestBranchAndBoundIncumbent(t *testing.T) {
+    c := []float64{-10.0, -13.0, -7.0, -8.0}
+    A := [][]float64{{3.0, 4.0, 2.0, 3.0}}
+    b := []float64{7.0}
+    types := []VarType{Binary, Binary, Binary, Binary}
+    calls := 0
+    var seen float64
+    stop := func(x []float64, fx float64) bool {
+        calls++
+        seen = fx
+        return true
+    }
+    x, fx, bound, nodes, err := BranchAndBound(c, A, b, nil, nil, types, DepthFirst, stop, 0.0, 1, 1000)
+    if err != nil || calls != 1 || fx != seen || x == nil || bound > fx {
+        t.Fatalf(`BranchAndBound(c, A, b, nil, nil, types, DepthFirst, stop, 0, 1, 1000) = %v, %f, %f, %d, %v with %d calls, want the first incumbent, nil`, x, fx, bound, nodes, err, calls)
+    }
+}
+
+// TestBranchAndBoundGap calls linprog.BranchAndBound with a binary knapsack problem and a large gap tolerance,
+// checking that the returned solution is within the gap of the bound.
+func TestBranchAndBoundGap(t *testing.T) {
+    c := []float64{-10.0, -13.0, -7.0, -8.0}
+    A := [][]float64{{3.0, 4.0, 2.0, 3.0}}
+    b := []float64{7.0}
+    types := []VarType{Binary, Binary, Binary, Binary}
+    gap := 0.2
+    x, fx, bound, nodes, err := BranchAndBound(c, A, b, nil, nil, types, DepthFirst, nil, gap, 1, 1000)
+    if err != nil || x == nil || bound > fx || (fx - bound)/math.Max(1.0, math.Abs(fx)) > gap {
+        t.Fatalf(`BranchAndBound(c, A, b, nil, nil, types, DepthFirst, nil, 0.2, 1, 1000) = %v, %f, %f, %d, %v, want a solution within the gap, nil`, x, fx, bound, nodes, err)
+    }
+}
@@ -0,0 +1,254 @@
+package linprog
+
+import (
+    "errors"
+    "math"
+)
+
+// simplexTol is the tolerance for reduced costs, pivot elements and feasibility in the simplex method
+const simplexTol = 1e-9
+
+// tableau holds the state of the revised simplex method, the standard form matrix extended by the artificial columns,
+// the basis, the explicit inverse of the basis matrix and the values of the basic variables
+type tableau struct {
+    A       [][]float64
+    b       []float64
+    basis   []int
+    Binv    [][]float64
+    xB      []float64
+    allowed []bool
+    pivots  int
+}
+
+// column returns column j of A
+func (t *tableau) column(j int) []float64 {
+    a := make([]float64, len(t.A))
+    for i := range t.A {
+        a[i] = t.A[i][j]
+    }
+    return a
+}
+
+// ftran returns Binv a
+func (t *tableau) ftran(a []float64) []float64 {
+    w := make([]float64, len(a))
+    for i := range t.Binv {
+        w[i] = dot(t.Binv[i], a)
+    }
+    return w
+}
+
+// prices returns the simplex multipliers y' = cB' Binv for the costs c
+func (t *tableau) prices(c []float64) []float64 {
+    y := make([]float64, len(t.basis))
+    for r, j := range t.basis {
+        if c[j] != 0.0 {
+            for i := range y {
+                y[i] += c[j]*t.Binv[r][i]
+            }
+        }
+    }
+    return y
+}
+
+// pivot replaces the basic variable in row r by column j with w = Binv a_j, the inverse is updated in product form and
+// recomputed from scratch every 50 pivots to limit the accumulation of rounding errors
+func (t *tableau) pivot(r int, j int, w []float64) {
+    t.basis[r] = j
+    t.pivots++
+    if t.pivots%50 == 0 {
+        m := len(t.basis)
+        B := make([][]float64, m)
+        for i := range B {
+            B[i] = make([]float64, m)
+            for k, l := range t.basis {
+                B[i][k] = t.A[i][l]
+            }
+        }
+        if Binv, ok := invert(B); ok {
+            t.Binv = Binv
+            t.xB = t.ftran(t.b)
+            return
+        }
+    }
+    pr := w[r]
+    for k := range t.Binv[r] {
+        t.Binv[r][k] /= pr
+    }
+    t.xB[r] /= pr
+    for i := range t.Binv {
+        if i != r && w[i] != 0.0 {
+            for k := range t.Binv[i] {
+                t.Binv[i][k] -= w[i]*t.Binv[r][k]
+            }
+            t.xB[i] -= w[i]*t.xB[r]
+        }
+    }
+}
+
+// optimize runs simplex iterations for the costs c until no allowed column has a negative reduced cost. Columns are
+// chosen by the most negative reduced cost (Dantzig), after a run of degenerate pivots the method switches to Bland's
+// rule which can not cycle, and back to Dantzig after the next nondegenerate pivot.
+func (t *tableau) optimize(c []float64, iter *int, maxit int) error {
+    degenerate := 0
+    isbasic := make([]bool, len(c))
+    for ; *iter < maxit; *iter ++ {
+        for j := range isbasic {
+            isbasic[j] = false
+        }
+        for _, j := range t.basis {
+            isbasic[j] = true
+        }
+        y := t.prices(c)
+        bland := degenerate > 10
+        q := -1
+        best := -simplexTol
+        for j := range c {
+            if isbasic[j] || !t.allowed[j] {
+                continue
+            }
+            d := c[j]
+            for i := range y {
+                d -= y[i]*t.A[i][j]
+            }
+            if d < best {
+                q, best = j, d
+                if bland {
+                    break
+                }
+            }
+        }
+        if q < 0 {
+            return nil
+        }
+        w := t.ftran(t.column(q))
+        r := -1
+        ratio := math.Inf(1)
+        for i := range w {
+            if w[i] > simplexTol {
+                s := t.xB[i]/w[i]
+                if s < ratio - simplexTol || (s <= ratio + simplexTol && t.basis[i] < t.basis[r]) {
+                    r, ratio = i, s
+                }
+            }
+        }
+        if r < 0 {
+            return ErrUnbounded
+        }
+        if ratio <= simplexTol {
+            degenerate++
+        } else {
+            degenerate = 0
+        }
+        t.pivot(r, q, w)
+    }
+    return errors.New("Maximum iterations reached before optimality")
+}
+
+// Simplex (Two-phase revised simplex method)
+// Solves min c'x subject to A x <= b, Aeq x = beq and x >= 0. Phase one minimizes the sum of artificial variables to
+// find a feasible basis, phase two minimizes c'x from there. The duals y are the sensitivities of the optimal value to
+// b followed by beq (nonpositive for the inequalities) and the reduced costs are d = c - A'y - Aeq'yeq (nonnegative).
+// input:
+// the cost vector (c), the inequality matrix (A, nil for none), its right hand side (b), the equality matrix (Aeq, nil for none), its right hand side (beq), maximum iterations (iter)
+// output:
+// the optimal x (x), objective value (fx), duals of the rows of A and Aeq (y), reduced costs (d), iterations done (iter)
+func Simplex(c []float64, A [][]float64, b []float64, Aeq [][]float64, beq []float64, maxit int) (x []float64, fx float64, y []float64, d []float64, iter int, err error) {
+    s, err := standardForm(c, A, b, Aeq, beq)
+    if err != nil {
+        return nil, 0.0, nil, nil, 0, err
+    }
+    m := len(s.b)
+    ns := len(s.c)
+    // Slacks of rows that were not negated form part of the initial basis, the other rows get an artificial column
+    t := &tableau{A: make([][]float64, m), b: s.b, basis: make([]int, m), Binv: make([][]float64, m), xB: append([]float64(nil), s.b...)}
+    na := 0
+    for i := 0; i < m; i++ {
+        if i >= s.mi || s.sign[i] < 0.0 {
+            na++
+        }
+    }
+    k := ns
+    for i := 0; i < m; i++ {
+        t.A[i] = make([]float64, ns + na)
+        copy(t.A[i], s.A[i])
+        t.Binv[i] = make([]float64, m)
+        t.Binv[i][i] = 1.0
+        if i < s.mi && s.sign[i] > 0.0 {
+            t.basis[i] = s.n + i
+        } else {
+            t.A[i][k] = 1.0
+            t.basis[i] = k
+            k++
+        }
+    }
+    t.allowed = make([]bool, ns + na)
+    for j := range t.allowed {
+        t.allowed[j] = true
+    }
+    iter = 0
+    if na > 0 {
+        c1 := make([]float64, ns + na)
+        for j := ns; j < ns + na; j++ {
+            c1[j] = 1.0
+        }
+        if err = t.optimize(c1, &iter, maxit); err != nil {
+            return nil, 0.0, nil, nil, iter, err
+        }
+        infeasibility := 0.0
+        for r, j := range t.basis {
+            if j >= ns {
+                infeasibility += t.xB[r]
+            }
+        }
+        if infeasibility > simplexTol*math.Max(1.0, norminf(s.b)) {
+            return nil, 0.0, nil, nil, iter, ErrInfeasible
+        }
+        // Drive the artificial variables out of the basis, an artificial that can not leave belongs to a redundant row
+        for r, j := range t.basis {
+            if j < ns {
+                continue
+            }
+            for q := 0; q < ns; q++ {
+                isbasic := false
+                for _, l := range t.basis {
+                    isbasic = isbasic || l == q
+                }
+                if isbasic {
+                    continue
+                }
+                w := t.ftran(t.column(q))
+                if math.Abs(w[r]) > simplexTol {
+                    t.pivot(r, q, w)
+                    break
+                }
+            }
+        }
+        for j := ns; j < ns + na; j++ {
+            t.allowed[j] = false
+        }
+    }
+    c2 := make([]float64, ns + na)
+    copy(c2, s.c)
+    if err = t.optimize(c2, &iter, maxit); err != nil {
+        return nil, 0.0, nil, nil, iter, err
+    }
+    xs := make([]float64, ns + na)
+    for r, j := range t.basis {
+        xs[j] = math.Max(t.xB[r], 0.0)
+    }
+    x = xs[:s.n]
+    ys := t.prices(c2)
+    y = make([]float64, m)
+    for i := range y {
+        y[i] = s.sign[i]*ys[i]
+    }
+    d = make([]float64, s.n)
+    for j := range d {
+        d[j] = s.c[j]
+        for i := range ys {
+            d[j] -= ys[i]*s.A[i][j]
+        }
+    }
+    return x, dot(c, x), y, d, iter, nil
+}
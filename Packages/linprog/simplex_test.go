@@ -0,0 +1,113 @@
+package linprog
+
+import (
+    "testing"
+    "fmt"
+    "math"
+)
+
+// approxEqual reports whether x and want agree to within tol entry by entry
+func approxEqual(x []float64, want []float64, tol float64) bool {
+    if len(x) != len(want) {
+        return false
+    }
+    for i := range x {
+        if math.Abs(x[i] - want[i]) > tol {
+            return false
+        }
+    }
+    return true
+}
+
+// TestSimplex calls linprog.Simplex with the product mix problem max 3 x0 + 5 x1 subject to x0 <= 4, 2 x1 <= 12 and
+// 3 x0 + 2 x1 <= 18, checking for the optimum, the duals and the reduced costs.
+func TestSimplex(t *testing.T) {
+    c := []float64{-3.0, -5.0}
+    A := [][]float64{{1.0, 0.0}, {0.0, 2.0}, {3.0, 2.0}}
+    b := []float64{4.0, 12.0, 18.0}
+    maxit := 100
+    x, fx, y, d, iter, err := Simplex(c, A, b, nil, nil, maxit)
+    msg := fmt.Sprintf("%v, %f, %v, %v, %d", x, fx, y, d, iter)
+    want := fmt.Sprintf("[2 6], -36, [0 -1.5 -1], [0 0], %d < %d", iter, maxit)
+    if err != nil || !approxEqual(x, []float64{2.0, 6.0}, 1e-12) || math.Abs(fx + 36.0) > 1e-12 || !approxEqual(y, []float64{0.0, -1.5, -1.0}, 1e-12) || !approxEqual(d, []float64{0.0, 0.0}, 1e-12) || iter >= maxit {
+        t.Fatalf(`Simplex(c, A, b, nil, nil, 100) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestSimplexMixed calls linprog.Simplex with min 2 x0 + 3 x1 subject to x0 + x1 >= 4 written as -x0 - x1 <= -4 and
+// x0 - x1 = 1, checking for the optimum and the duals.
+func TestSimplexMixed(t *testing.T) {
+    c := []float64{2.0, 3.0}
+    A := [][]float64{{-1.0, -1.0}}
+    b := []float64{-4.0}
+    Aeq := [][]float64{{1.0, -1.0}}
+    beq := []float64{1.0}
+    x, fx, y, d, iter, err := Simplex(c, A, b, Aeq, beq, 100)
+    msg := fmt.Sprintf("%v, %f, %v, %v, %d", x, fx, y, d, iter)
+    want := "[2.5 1.5], 9.5, [-2.5 -0.5], [0 0]"
+    if err != nil || !approxEqual(x, []float64{2.5, 1.5}, 1e-12) || math.Abs(fx - 9.5) > 1e-12 || !approxEqual(y, []float64{-2.5, -0.5}, 1e-12) || !approxEqual(d, []float64{0.0, 0.0}, 1e-12) {
+        t.Fatalf(`Simplex(c, A, b, Aeq, beq, 100) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestSimplexInfeasible calls linprog.Simplex with x0 + x1 <= 1 and x0 + x1 >= 2,
+// checking for ErrInfeasible.
+func TestSimplexInfeasible(t *testing.T) {
+    c := []float64{1.0, 1.0}
+    A := [][]float64{{1.0, 1.0}, {-1.0, -1.0}}
+    b := []float64{1.0, -2.0}
+    x, fx, y, d, iter, err := Simplex(c, A, b, nil, nil, 100)
+    if x != nil || fx != 0.0 || y != nil || d != nil || err != ErrInfeasible {
+        t.Fatalf(`Simplex(c, A, b, nil, nil, 100) = %v, %f, %v, %v, %d, %v, want nil, 0, nil, nil, ErrInfeasible`, x, fx, y, d, iter, err)
+    }
+}
+
+// TestSimplexUnbounded calls linprog.Simplex with min -x0 subject to x0 - x1 <= 1,
+// checking for ErrUnbounded.
+func TestSimplexUnbounded(t *testing.T) {
+    c := []float64{-1.0, 0.0}
+    A := [][]float64{{1.0, -1.0}}
+    b := []float64{1.0}
+    x, fx, y, d, iter, err := Simplex(c, A, b, nil, nil, 100)
+    if x != nil || fx != 0.0 || y != nil || d != nil || err != ErrUnbounded {
+        t.Fatalf(`Simplex(c, A, b, nil, nil, 100) = %v, %f, %v, %v, %d, %v, want nil, 0, nil, nil, ErrUnbounded`, x, fx, y, d, iter, err)
+    }
+}
+
+// TestSimplexCycling calls linprog.Simplex with Beale's example, which cycles under the textbook pivoting rule,
+// checking for the optimum.
+func TestSimplexCycling(t *testing.T) {
+    c := []float64{-0.75, 150.0, -0.02, 6.0}
+    A := [][]float64{{0.25, -60.0, -0.04, 9.0}, {0.5, -90.0, -0.02, 3.0}, {0.0, 0.0, 1.0, 0.0}}
+    b := []float64{0.0, 0.0, 1.0}
+    maxit := 100
+    x, fx, _, _, iter, err := Simplex(c, A, b, nil, nil, maxit)
+    msg := fmt.Sprintf("%v, %f, %d", x, fx, iter)
+    want := fmt.Sprintf("[0.04 0 1 0], -0.05, %d < %d", iter, maxit)
+    if err != nil || !approxEqual(x, []float64{0.04, 0.0, 1.0, 0.0}, 1e-12) || math.Abs(fx + 0.05) > 1e-12 || iter >= maxit {
+        t.Fatalf(`Simplex(c, A, b, nil, nil, 100) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestSimplexRedundant calls linprog.Simplex with the equalities x0 + x1 = 1 and 2 x0 + 2 x1 = 2,
+// checking for the optimum despite the redundant row.
+func TestSimplexRedundant(t *testing.T) {
+    c := []float64{1.0, 2.0}
+    Aeq := [][]float64{{1.0, 1.0}, {2.0, 2.0}}
+    beq := []float64{1.0, 2.0}
+    x, fx, y, d, iter, err := Simplex(c, nil, nil, Aeq, beq, 100)
+    msg := fmt.Sprintf("%v, %f, %v, %v, %d", x, fx, y, d, iter)
+    want := "[1 0], 1, y, [0 1]"
+    if err != nil || !approxEqual(x, []float64{1.0, 0.0}, 1e-12) || math.Abs(fx - 1.0) > 1e-12 || !approxEqual(d, []float64{0.0, 1.0}, 1e-12) {
+        t.Fatalf(`Simplex(c, nil, nil, Aeq, beq, 100) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestSimplexEmpty calls linprog.Simplex with an empty cost vector,
+// checking for an error.
+func TestSimplexEmpty(t *testing.T) {
+    x, fx, y, d, iter, err := Simplex(nil, nil, nil, nil, nil, 100)
+    if x != nil || fx != 0.0 || y != nil || d != nil || iter != 0 || err == nil {
+        t.Fatalf(`Simplex(nil, nil, nil, nil, nil, 100) = %v, %f, %v, %v, %d, %v, want nil, 0, nil, nil, 0, error`, x, fx, y, d, iter, err)
+    }
+}
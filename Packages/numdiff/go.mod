@@ -0,0 +1,3 @@
+module example.com/numdiff
+
+go 1.15
@@ -0,0 +1,319 @@
+package numdiff
+
+import (
+    "errors"
+    "math"
+)
+
+// eps is the machine epsilon for float64
+const eps = 2.220446049250313e-16
+
+// Weights (Fornberg's algorithm)
+// input:
+// the point to differentiate at (z), the stencil points (points), the derivative order (n)
+// output:
+// the finite difference weights for the n'th derivative at z, one per stencil point (w)
+func Weights(z float64, points []float64, n int) (w []float64, err error) {
+    if n < 0 {
+        return nil, errors.New("n must be greater than or equal to 0")
+    }
+    if len(points) <= n {
+        return nil, errors.New("the number of points must be greater than n")
+    }
+    m := len(points)
+    c := make([][]float64, m)
+    for i := range c {
+        c[i] = make([]float64, n + 1)
+    }
+    c1 := 1.0
+    c4 := points[0] - z
+    c[0][0] = 1.0
+    for i := 1; i < m; i++ {
+        mn := i
+        if mn > n {
+            mn = n
+        }
+        c2 := 1.0
+        c5 := c4
+        c4 = points[i] - z
+        for j := 0; j < i; j++ {
+            c3 := points[i] - points[j]
+            if c3 == 0.0 {
+                return nil, errors.New("the stencil points must be distinct")
+            }
+            c2 *= c3
+            if j == i - 1 {
+                for k := mn; k > 0; k-- {
+                    c[i][k] = c1*(float64(k)*c[i-1][k-1] - c5*c[i-1][k])/c2
+                }
+                c[i][0] = -c1*c5*c[i-1][0]/c2
+            }
+            for k := mn; k > 0; k-- {
+                c[j][k] = (c4*c[j][k] - float64(k)*c[j][k-1])/c3
+            }
+            c[j][0] = c4*c[j][0]/c3
+        }
+        c1 = c2
+    }
+    w = make([]float64, m)
+    for i := range w {
+        w[i] = c[i][n]
+    }
+    return w, nil
+}
+
+// Step returns a step size for an n'th derivative approximation of accuracy order p at x,
+// balancing the truncation error against the rounding error
+func Step(x float64, n int, p int) float64 {
+    return math.Pow(eps, 1.0/float64(n + p))*math.Max(math.Abs(x), 1.0)
+}
+
+// stencil evaluates the finite difference formula with offsets (in units of h) given by offsets
+func stencil(f func(float64) float64, x float64, h float64, n int, offsets []float64) (float64, error) {
+    w, err := Weights(0.0, offsets, n)
+    if err != nil {
+        return 0.0, err
+    }
+    d := 0.0
+    for i, o := range offsets {
+        if w[i] != 0.0 {
+            d += w[i]*f(x + o*h)
+        }
+    }
+    return d/math.Pow(h, float64(n)), nil
+}
+
+// checkOrders validates the derivative order (n) and accuracy order (p)
+func checkOrders(n int, p int) error {
+    if n < 1 {
+        return errors.New("n must be greater than 0")
+    }
+    if p < 1 {
+        return errors.New("p must be greater than 0")
+    }
+    return nil
+}
+
+// Central (Central finite difference)
+// input:
+// the function to differentiate (f), the point (x), step size (h, h <= 0 selects the step with Step), derivative order (n), even accuracy order (p)
+// output:
+// the estimated n'th derivative (d)
+func Central(f func(float64) float64, x float64, h float64, n int, p int) (d float64, err error) {
+    if err = checkOrders(n, p); err != nil {
+        return 0.0, err
+    }
+    if p % 2 != 0 {
+        return 0.0, errors.New("p must be even for central differences")
+    }
+    if h <= 0.0 {
+        h = Step(x, n, p)
+    }
+    m := (n - 1)/2 + p/2
+    offsets := make([]float64, 2*m + 1)
+    for i := range offsets {
+        offsets[i] = float64(i - m)
+    }
+    return stencil(f, x, h, n, offsets)
+}
+
+// Forward (Forward finite difference)
+// input:
+// the function to differentiate (f), the point (x), step size (h, h <= 0 selects the step with Step), derivative order (n), accuracy order (p)
+// output:
+// the estimated n'th derivative (d)
+func Forward(f func(float64) float64, x float64, h float64, n int, p int) (d float64, err error) {
+    if err = checkOrders(n, p); err != nil {
+        return 0.0, err
+    }
+    if h <= 0.0 {
+        h = Step(x, n, p)
+    }
+    offsets := make([]float64, n + p)
+    for i := range offsets {
+        offsets[i] = float64(i)
+    }
+    return stencil(f, x, h, n, offsets)
+}
+
+// Backward (Backward finite difference)
+// input:
+// the function to differentiate (f), the point (x), step size (h, h <= 0 selects the step with Step), derivative order (n), accuracy order (p)
+// output:
+// the estimated n'th derivative (d)
+func Backward(f func(float64) float64, x float64, h float64, n int, p int) (d float64, err error) {
+    if err = checkOrders(n, p); err != nil {
+        return 0.0, err
+    }
+    if h <= 0.0 {
+        h = Step(x, n, p)
+    }
+    offsets := make([]float64, n + p)
+    for i := range offsets {
+        offsets[i] = -float64(i)
+    }
+    return stencil(f, x, h, n, offsets)
+}
+
+// Richardson (Richardson extrapolation of central differences)
+// Successively halves the step and eliminates the even powers of h from the truncation error,
+// stopping when the error estimate no longer improves
+// input:
+// the function to differentiate (f), the point (x), initial step size (h), derivative order (n), error deviation (es), maximum iterations (iter)
+// output:
+// the estimated n'th derivative (d), error estimate (ea), iterations done (iter)
+func Richardson(f func(float64) float64, x float64, h float64, n int, es float64, maxit int) (d float64, ea float64, iter int, err error) {
+    if es < 0.0 {
+        return 0.0, 0.0, 0, errors.New("es must be greater than 0")
+    }
+    if h <= 0.0 {
+        return 0.0, 0.0, 0, errors.New("h must be greater than 0")
+    }
+    d, err = Central(f, x, h, n, 2)
+    if err != nil {
+        return 0.0, 0.0, 0, err
+    }
+    prev := []float64{d}
+    ea = math.Inf(1)
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        h /= 2.0
+        row := make([]float64, len(prev) + 1)
+        row[0], _ = Central(f, x, h, n, 2)
+        factor := 1.0
+        for k := 1; k < len(row); k++ {
+            factor *= 4.0
+            row[k] = row[k-1] + (row[k-1] - prev[k-1])/(factor - 1.0)
+            errk := math.Max(math.Abs(row[k] - row[k-1]), math.Abs(row[k] - prev[k-1]))
+            if errk <= ea {
+                ea = errk
+                d = row[k]
+            }
+        }
+        k := len(row) - 1
+        if math.Abs(row[k] - prev[k-1]) >= 2.0*ea { // rounding error has taken over
+            break
+        }
+        if ea <= es {
+            break
+        }
+        prev = row
+    }
+    return d, ea, iter, nil
+}
+
+// ComplexStep (Complex-step derivative)
+// The derivative of an analytic function is Im(f(x + ih))/h, which has no subtractive cancellation so h may be tiny
+// input:
+// the function extended to complex arguments (f), the point (x), step size (h, h <= 0 selects 1e-20*max(|x|, 1))
+// output:
+// the estimated first derivative (d)
+func ComplexStep(f func(complex128) complex128, x float64, h float64) (d float64) {
+    if h <= 0.0 {
+        h = 1e-20*math.Max(math.Abs(x), 1.0)
+    }
+    return imag(f(complex(x, h)))/h
+}
+
+// Gradient (Central difference gradient)
+// input:
+// the function of several variables (f), the point (x)
+// output:
+// the estimated gradient (grad)
+func Gradient(f func([]float64) float64, x []float64) (grad []float64, err error) {
+    if len(x) == 0 {
+        return nil, errors.New("x must not be empty")
+    }
+    xh := append([]float64(nil), x...)
+    grad = make([]float64, len(x))
+    for i := range x {
+        h := Step(x[i], 1, 2)
+        xh[i] = x[i] + h
+        fp := f(xh)
+        xh[i] = x[i] - h
+        fm := f(xh)
+        xh[i] = x[i]
+        grad[i] = (fp - fm)/(2.0*h)
+    }
+    return grad, nil
+}
+
+// Jacobian (Central difference Jacobian)
+// input:
+// the vector valued function (f), the point (x)
+// output:
+// the estimated Jacobian with jac[i][j] = dfi/dxj (jac)
+func Jacobian(f func([]float64) []float64, x []float64) (jac [][]float64, err error) {
+    if len(x) == 0 {
+        return nil, errors.New("x must not be empty")
+    }
+    xh := append([]float64(nil), x...)
+    for j := range x {
+        h := Step(x[j], 1, 2)
+        xh[j] = x[j] + h
+        fp := f(xh)
+        xh[j] = x[j] - h
+        fm := f(xh)
+        xh[j] = x[j]
+        if len(fp) != len(fm) {
+            return nil, errors.New("f must return vectors of a fixed length")
+        }
+        if jac == nil {
+            jac = make([][]float64, len(fp))
+            for i := range jac {
+                jac[i] = make([]float64, len(x))
+            }
+        }
+        if len(fp) != len(jac) {
+            return nil, errors.New("f must return vectors of a fixed length")
+        }
+        for i := range fp {
+            jac[i][j] = (fp[i] - fm[i])/(2.0*h)
+        }
+    }
+    return jac, nil
+}
+
+// Hessian (Central difference Hessian)
+// input:
+// the function of several variables (f), the point (x)
+// output:
+// the estimated symmetric Hessian (hess)
+func Hessian(f func([]float64) float64, x []float64) (hess [][]float64, err error) {
+    if len(x) == 0 {
+        return nil, errors.New("x must not be empty")
+    }
+    n := len(x)
+    xh := append([]float64(nil), x...)
+    h := make([]float64, n)
+    for i := range h {
+        h[i] = Step(x[i], 2, 2)
+    }
+    f0 := f(xh)
+    hess = make([][]float64, n)
+    for i := range hess {
+        hess[i] = make([]float64, n)
+    }
+    for i := 0; i < n; i++ {
+        xh[i] = x[i] + h[i]
+        fp := f(xh)
+        xh[i] = x[i] - h[i]
+        fm := f(xh)
+        xh[i] = x[i]
+        hess[i][i] = (fp - 2.0*f0 + fm)/(h[i]*h[i])
+        for j := 0; j < i; j++ {
+            xh[i], xh[j] = x[i] + h[i], x[j] + h[j]
+            fpp := f(xh)
+            xh[j] = x[j] - h[j]
+            fpm := f(xh)
+            xh[i] = x[i] - h[i]
+            fmm := f(xh)
+            xh[j] = x[j] + h[j]
+            fmp := f(xh)
+            xh[i], xh[j] = x[i], x[j]
+            hess[i][j] = (fpp - fpm - fmp + fmm)/(4.0*h[i]*h[j])
+            hess[j][i] = hess[i][j]
+        }
+    }
+    return hess, nil
+}
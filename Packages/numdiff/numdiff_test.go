@@ -0,0 +1,145 @@
+package numdiff
+
+import (
+    "testing"
+    "fmt"
+    "math"
+    "math/cmplx"
+)
+
+// TestWeights calls numdiff.Weights with a five point central stencil, checking
+// the classical fourth order weights of the first derivative.
+func TestWeights(t *testing.T) {
+    points := []float64{-2.0, -1.0, 0.0, 1.0, 2.0}
+    want := []float64{1.0/12.0, -2.0/3.0, 0.0, 2.0/3.0, -1.0/12.0}
+    w, err := Weights(0.0, points, 1)
+    if err != nil {
+        t.Fatalf(`Weights(0, [-2..2], 1) = %v, %v, want match for %v, nil`, w, err, want)
+    }
+    for i := range w {
+        if math.Abs(w[i] - want[i]) > 1e-14 {
+            t.Fatalf(`Weights(0, [-2..2], 1) = %v, %v, want match for %v, nil`, w, err, want)
+        }
+    }
+}
+
+// TestWeightsTooFewPoints calls numdiff.Weights with fewer points than the derivative order requires,
+// checking for an error.
+func TestWeightsTooFewPoints(t *testing.T) {
+    w, err := Weights(0.0, []float64{0.0, 1.0}, 2)
+    if w != nil || err == nil {
+        t.Fatalf(`Weights(0, [0, 1], 2) = %v, %v, want nil, error`, w, err)
+    }
+}
+
+// TestFiniteDifferences calls numdiff.Central, numdiff.Forward and numdiff.Backward for several derivative and
+// accuracy orders, checking against the derivatives of exp.
+func TestFiniteDifferences(t *testing.T) {
+    x := 0.5
+    want := math.Exp(x)
+    cases := []struct {
+        name string
+        method func(func(float64) float64, float64, float64, int, int) (float64, error)
+        n, p int
+        es float64
+    }{
+        {"Central", Central, 1, 2, 1e-9},
+        {"Central", Central, 1, 6, 1e-12},
+        {"Central", Central, 2, 2, 1e-6},
+        {"Central", Central, 3, 4, 1e-3},
+        {"Forward", Forward, 1, 1, 1e-7},
+        {"Forward", Forward, 1, 4, 1e-10},
+        {"Forward", Forward, 2, 3, 1e-5},
+        {"Backward", Backward, 1, 3, 1e-9},
+        {"Backward", Backward, 2, 2, 1e-5},
+    }
+    for _, c := range cases {
+        d, err := c.method(math.Exp, x, 0.0, c.n, c.p)
+        if err != nil || math.Abs(d - want) > c.es*want {
+            t.Fatalf(`%s(exp, 0.5, 0, %d, %d) = %f, %v, want %f, nil`, c.name, c.n, c.p, d, err, want)
+        }
+    }
+}
+
+// TestCentralOddOrder calls numdiff.Central with an odd accuracy order, checking for an error.
+func TestCentralOddOrder(t *testing.T) {
+    d, err := Central(math.Exp, 0.0, 0.0, 1, 3)
+    if d != 0.0 || err == nil {
+        t.Fatalf(`Central(exp, 0, 0, 1, 3) = %f, %v, want 0, error`, d, err)
+    }
+}
+
+// TestRichardson calls numdiff.Richardson with a function, point, initial step, derivative order, error limit
+// and max iterations, checking for a valid return value and error estimate.
+func TestRichardson(t *testing.T) {
+    x := 1.0
+    h := 0.5
+    es := 1e-12
+    maxit := 20
+    f := func(x float64) float64 {
+        return math.Sin(x)/x
+    }
+    want := (x*math.Cos(x) - math.Sin(x))/(x*x)
+    d, ea, iter, err := Richardson(f, x, h, 1, es, maxit)
+    msg := fmt.Sprintf("%.15f, %g, %d", d, ea, iter)
+    wantmsg := fmt.Sprintf("%.15f, %g, %d < %d", want, es, iter, maxit)
+    if err != nil || math.Abs(d - want) > 1e-10 || ea > 1e-10 || iter > maxit {
+        t.Fatalf(`Richardson(f: x->sin(x)/x, 1, 0.5, 1, 1e-12, 20) = %q, %v, want match for %q, nil`, msg, err, wantmsg)
+    }
+}
+
+// TestComplexStep calls numdiff.ComplexStep with an analytic function, checking that the derivative is
+// accurate to machine precision.
+func TestComplexStep(t *testing.T) {
+    x := 1.5
+    f := func(z complex128) complex128 {
+        return cmplx.Exp(z)/cmplx.Sqrt(cmplx.Pow(cmplx.Sin(z), 3) + cmplx.Pow(cmplx.Cos(z), 3))
+    }
+    g := func(x float64) float64 {
+        return real(f(complex(x, 0.0)))
+    }
+    want, _, _, _ := Richardson(g, x, 0.1, 1, 1e-13, 30)
+    d := ComplexStep(f, x, 0.0)
+    if math.Abs(d - want) > 1e-9*math.Abs(want) {
+        t.Fatalf(`ComplexStep(f, 1.5, 0) = %.15f, want %.15f`, d, want)
+    }
+}
+
+// TestGradientJacobianHessian calls numdiff.Gradient, numdiff.Jacobian and numdiff.Hessian on the Rosenbrock
+// function, checking against the analytic derivatives.
+func TestGradientJacobianHessian(t *testing.T) {
+    x := []float64{-1.2, 1.0}
+    f := func(x []float64) float64 {
+        return (1.0 - x[0])*(1.0 - x[0]) + 100.0*(x[1] - x[0]*x[0])*(x[1] - x[0]*x[0])
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{-2.0*(1.0 - x[0]) - 400.0*x[0]*(x[1] - x[0]*x[0]), 200.0*(x[1] - x[0]*x[0])}
+    }
+    gradwant := grad(x)
+    hesswant := [][]float64{{2.0 - 400.0*(x[1] - 3.0*x[0]*x[0]), -400.0*x[0]}, {-400.0*x[0], 200.0}}
+    g, err := Gradient(f, x)
+    if err != nil {
+        t.Fatalf(`Gradient(rosenbrock, [-1.2, 1]) = %v, %v, want %v, nil`, g, err, gradwant)
+    }
+    jac, err := Jacobian(grad, x)
+    if err != nil {
+        t.Fatalf(`Jacobian(grad rosenbrock, [-1.2, 1]) = %v, %v, want %v, nil`, jac, err, hesswant)
+    }
+    hess, err := Hessian(f, x)
+    if err != nil {
+        t.Fatalf(`Hessian(rosenbrock, [-1.2, 1]) = %v, %v, want %v, nil`, hess, err, hesswant)
+    }
+    for i := range x {
+        if math.Abs(g[i] - gradwant[i]) > 1e-6*math.Abs(gradwant[i]) {
+            t.Fatalf(`Gradient(rosenbrock, [-1.2, 1]) = %v, want %v`, g, gradwant)
+        }
+        for j := range x {
+            if math.Abs(jac[i][j] - hesswant[i][j]) > 1e-6*math.Abs(hesswant[i][j]) {
+                t.Fatalf(`Jacobian(grad rosenbrock, [-1.2, 1]) = %v, want %v`, jac, hesswant)
+            }
+            if math.Abs(hess[i][j] - hesswant[i][j]) > 1e-4*math.Abs(hesswant[i][j]) {
+                t.Fatalf(`Hessian(rosenbrock, [-1.2, 1]) = %v, want %v`, hess, hesswant)
+            }
+        }
+    }
+}
@@ -0,0 +1,142 @@
+package optimization
+
+import (
+    "errors"
+    "math"
+    "math/rand"
+)
+
+// Cooling returns the temperature of simulated annealing
+// input:
+// the initial temperature (t0), the iteration (k)
+// output:
+// the temperature at iteration k (t)
+type Cooling func(t0 float64, k int) float64
+
+// GeometricCooling (Exponential cooling schedule)
+// input:
+// cooling factor per iteration in (0, 1) (alpha)
+// output:
+// a schedule with t = t0*alpha^k
+func GeometricCooling(alpha float64) Cooling {
+    return func(t0 float64, k int) float64 {
+        return t0*math.Pow(alpha, float64(k))
+    }
+}
+
+// LogarithmicCooling (Logarithmic cooling schedule of Geman and Geman)
+// Cools slowly enough for the convergence guarantees of simulated annealing, but is usually too slow in practice
+// output:
+// a schedule with t = t0/ln(k + e)
+func LogarithmicCooling() Cooling {
+    return func(t0 float64, k int) float64 {
+        return t0/math.Log(float64(k) + math.E)
+    }
+}
+
+// FastCooling (Cauchy cooling schedule of fast simulated annealing)
+// output:
+// a schedule with t = t0/(k + 1)
+func FastCooling() Cooling {
+    return func(t0 float64, k int) float64 {
+        return t0/float64(k + 1)
+    }
+}
+
+// GaussianNeighbor (Gaussian neighbor generator for continuous states)
+// input:
+// standard deviation of the step in every coordinate (sigma)
+// output:
+// a neighbor generator returning x plus normally distributed steps
+func GaussianNeighbor(sigma float64) func(x []float64, rng *rand.Rand) []float64 {
+    return func(x []float64, rng *rand.Rand) []float64 {
+        y := make([]float64, len(x))
+        for i := range x {
+            y[i] = x[i] + sigma*rng.NormFloat64()
+        }
+        return y
+    }
+}
+
+// CauchyNeighbor (Cauchy neighbor generator for continuous states)
+// The heavy tails of the Cauchy distribution give occasional long jumps between distant basins
+// input:
+// scale of the step in every coordinate (gamma)
+// output:
+// a neighbor generator returning x plus Cauchy distributed steps
+func CauchyNeighbor(gamma float64) func(x []float64, rng *rand.Rand) []float64 {
+    return func(x []float64, rng *rand.Rand) []float64 {
+        y := make([]float64, len(x))
+        for i := range x {
+            y[i] = x[i] + gamma*math.Tan(math.Pi*(rng.Float64() - 0.5))
+        }
+        return y
+    }
+}
+
+// SimulatedAnnealingDiscrete (Simulated annealing for user defined state spaces)
+// Accepts a neighbor with the Metropolis criterion, always when it is better and with probability exp(-(fn - fc)/t)
+// when it is worse, and returns the best state visited. The states are opaque to the method, the neighbor function
+// must return a new state and leave its argument unchanged.
+// input:
+// the function to find the minimum for (f), initial state (s0), neighbor generator (neighbor), cooling schedule (cooling, nil uses GeometricCooling(0.995)), initial temperature (t0), seed of the random number generator (seed), final temperature (es), maximum iterations (iter)
+// output:
+// the best state (s), function value (fs), temperature at termination (ea), iterations done (iter)
+func SimulatedAnnealingDiscrete(f func(interface{}) float64, s0 interface{}, neighbor func(s interface{}, rng *rand.Rand) interface{}, cooling Cooling, t0 float64, seed int64, es float64, maxit int) (s interface{}, fs float64, ea float64, iter int, err error) {
+    if es <= 0.0 {
+        return nil, 0.0, 0.0, 0, errors.New("es must be greater than 0")
+    }
+    if t0 <= 0.0 {
+        return nil, 0.0, 0.0, 0, errors.New("t0 must be greater than 0")
+    }
+    if neighbor == nil {
+        return nil, 0.0, 0.0, 0, errors.New("neighbor must not be nil")
+    }
+    if cooling == nil {
+        cooling = GeometricCooling(0.995)
+    }
+    rng := rand.New(rand.NewSource(seed))
+    current := s0
+    fc := f(current)
+    s, fs = current, fc
+    ea = t0
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        ea = cooling(t0, iter)
+        if ea <= es {
+            break
+        }
+        next := neighbor(current, rng)
+        fn := f(next)
+        if fn <= fc || rng.Float64() < math.Exp(-(fn - fc)/ea) {
+            current, fc = next, fn
+            if fc < fs {
+                s, fs = current, fc
+            }
+        }
+    }
+    return s, fs, ea, iter, nil
+}
+
+// SimulatedAnnealing (Simulated annealing for continuous states)
+// input:
+// the function to find the minimum for (f), initial guess (x0), neighbor generator (neighbor, nil uses GaussianNeighbor(0.1)), cooling schedule (cooling, nil uses GeometricCooling(0.995)), initial temperature (t0), seed of the random number generator (seed), final temperature (es), maximum iterations (iter)
+// output:
+// the best x (x), function value (fx), temperature at termination (ea), iterations done (iter)
+func SimulatedAnnealing(f func([]float64) float64, x0 []float64, neighbor func(x []float64, rng *rand.Rand) []float64, cooling Cooling, t0 float64, seed int64, es float64, maxit int) (x []float64, fx float64, ea float64, iter int, err error) {
+    if len(x0) == 0 {
+        return nil, 0.0, 0.0, 0, errors.New("x0 must not be empty")
+    }
+    if neighbor == nil {
+        neighbor = GaussianNeighbor(0.1)
+    }
+    s, fx, ea, iter, err := SimulatedAnnealingDiscrete(func(s interface{}) float64 {
+        return f(s.([]float64))
+    }, append([]float64(nil), x0...), func(s interface{}, rng *rand.Rand) interface{} {
+        return neighbor(s.([]float64), rng)
+    }, cooling, t0, seed, es, maxit)
+    if err != nil {
+        return nil, 0.0, 0.0, 0, err
+    }
+    return s.([]float64), fx, ea, iter, nil
+}
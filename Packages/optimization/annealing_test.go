@@ -0,0 +1,111 @@
+package optimization
+
+import (
+    "testing"
+    "fmt"
+    "math"
+    "math/rand"
+)
+
+// TestSimulatedAnnealing calls optimization.SimulatedAnnealing with the tilted double well started in the basin of
+// the local minimum, checking for the global minimum near x0 = -1.
+func TestSimulatedAnnealing(t *testing.T) {
+    f := func(x []float64) float64 {
+        return x[0]*x[0]*x[0]*x[0] - 2.0*x[0]*x[0] + x[1]*x[1] + 0.1*x[0]
+    }
+    es := 1e-4
+    maxit := 5000
+    x, fx, ea, iter, err := SimulatedAnnealing(f, []float64{1.0, 0.0}, GaussianNeighbor(0.2), GeometricCooling(0.998), 1.0, 1, es, maxit)
+    msg := fmt.Sprintf("%v, %f, %g, %d", x, fx, ea, iter)
+    want := "[-1.02 0], -1.1, ea, iter"
+    if err != nil || math.Abs(x[0] + 1.0125) > 0.05 || math.Abs(x[1]) > 0.05 || fx > -1.09 {
+        t.Fatalf(`SimulatedAnnealing(f, [1, 0], GaussianNeighbor(0.2), GeometricCooling(0.998), 1, 1, 1e-4, 5000) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestSimulatedAnnealingRastrigin calls optimization.SimulatedAnnealing with the two dimensional Rastrigin function
+// and Cauchy neighbors, checking for the global minimum, and again with the same seed, checking for the same result.
+func TestSimulatedAnnealingRastrigin(t *testing.T) {
+    rastrigin := func(x []float64) float64 { // global minimum 0 at the origin surrounded by a grid of local minima
+        s := 10.0*float64(len(x))
+        for _, v := range x {
+            s += v*v - 10.0*math.Cos(2.0*math.Pi*v)
+        }
+        return s
+    }
+    x, fx, ea, iter, err := SimulatedAnnealing(rastrigin, []float64{3.2, -4.1}, CauchyNeighbor(0.3), GeometricCooling(0.999), 10.0, 7, 1e-3, 20000)
+    msg := fmt.Sprintf("%v, %f, %g, %d", x, fx, ea, iter)
+    want := "[0 0], 0, ea, iter"
+    if err != nil || math.Abs(x[0]) > 0.05 || math.Abs(x[1]) > 0.05 || fx > 0.5 {
+        t.Fatalf(`SimulatedAnnealing(rastrigin, [3.2, -4.1], CauchyNeighbor(0.3), GeometricCooling(0.999), 10, 7, 1e-3, 20000) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+    x2, fx2, ea2, iter2, err := SimulatedAnnealing(rastrigin, []float64{3.2, -4.1}, CauchyNeighbor(0.3), GeometricCooling(0.999), 10.0, 7, 1e-3, 20000)
+    if err != nil || x2[0] != x[0] || x2[1] != x[1] || fx2 != fx || ea2 != ea || iter2 != iter {
+        t.Fatalf(`SimulatedAnnealing with the same seed = %v, %f, %g, %d, %v, want %v, %f, %g, %d, nil`, x2, fx2, ea2, iter2, err, x, fx, ea, iter)
+    }
+}
+
+// TestSimulatedAnnealingDiscrete calls optimization.SimulatedAnnealingDiscrete with a traveling salesman problem on
+// eight cities on the unit circle and segment reversal neighbors, checking for the perimeter of the octagon.
+func TestSimulatedAnnealingDiscrete(t *testing.T) {
+    cities := make([][2]float64, 8)
+    for i := range cities {
+        cities[i] = [2]float64{math.Cos(2.0*math.Pi*float64(i)/8.0), math.Sin(2.0*math.Pi*float64(i)/8.0)}
+    }
+    length := func(s interface{}) float64 {
+        tour := s.([]int)
+        l := 0.0
+        for i := range tour {
+            a, b := cities[tour[i]], cities[tour[(i + 1)%len(tour)]]
+            l += math.Hypot(a[0] - b[0], a[1] - b[1])
+        }
+        return l
+    }
+    reverse := func(s interface{}, rng *rand.Rand) interface{} {
+        tour := append([]int(nil), s.([]int)...)
+        i, j := rng.Intn(len(tour)), rng.Intn(len(tour))
+        if i > j {
+            i, j = j, i
+        }
+        for ; i < j; i, j = i + 1, j - 1 {
+            tour[i], tour[j] = tour[j], tour[i]
+        }
+        return tour
+    }
+    s0 := []int{0, 3, 6, 1, 4, 7, 2, 5}
+    perimeter := 16.0*math.Sin(math.Pi/8.0)
+    s, fs, ea, iter, err := SimulatedAnnealingDiscrete(length, s0, reverse, FastCooling(), 1.0, 3, 1e-3, 5000)
+    msg := fmt.Sprintf("%v, %f, %g, %d", s, fs, ea, iter)
+    want := fmt.Sprintf("tour, %f, ea, iter", perimeter)
+    if err != nil || math.Abs(fs - perimeter) > 1e-12 {
+        t.Fatalf(`SimulatedAnnealingDiscrete(length, [0 3 6 1 4 7 2 5], reverse, FastCooling(), 1, 3, 1e-3, 5000) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestSimulatedAnnealingErrors calls optimization.SimulatedAnnealing and optimization.SimulatedAnnealingDiscrete with
+// invalid arguments, checking for errors.
+func TestSimulatedAnnealingErrors(t *testing.T) {
+    rastrigin := func(x []float64) float64 { // global minimum 0 at the origin surrounded by a grid of local minima
+        s := 10.0*float64(len(x))
+        for _, v := range x {
+            s += v*v - 10.0*math.Cos(2.0*math.Pi*v)
+        }
+        return s
+    }
+    x, fx, ea, iter, err := SimulatedAnnealing(rastrigin, nil, nil, nil, 1.0, 1, 1e-3, 10)
+    if x != nil || fx != 0.0 || ea != 0.0 || iter != 0 || err == nil {
+        t.Fatalf(`SimulatedAnnealing(rastrigin, nil, nil, nil, 1, 1, 1e-3, 10) = %v, %f, %g, %d, %v, want nil, 0, 0, 0, error`, x, fx, ea, iter, err)
+    }
+    s, fs, ea, iter, err := SimulatedAnnealingDiscrete(func(s interface{}) float64 { return 0.0 }, 0, nil, nil, 1.0, 1, 1e-3, 10)
+    if s != nil || fs != 0.0 || ea != 0.0 || iter != 0 || err == nil {
+        t.Fatalf(`SimulatedAnnealingDiscrete(f, 0, nil, nil, 1, 1, 1e-3, 10) = %v, %f, %g, %d, %v, want nil, 0, 0, 0, error`, s, fs, ea, iter, err)
+    }
+}
+
+// TestCooling calls the cooling schedules at iteration 0 and 9, checking for the expected temperatures.
+func TestCooling(t *testing.T) {
+    g, l, c := GeometricCooling(0.5), LogarithmicCooling(), FastCooling()
+    if g(2.0, 0) != 2.0 || math.Abs(g(2.0, 9) - 2.0/512.0) > 1e-15 || math.Abs(l(2.0, 0) - 2.0) > 1e-15 || math.Abs(l(2.0, 9) - 2.0/math.Log(9.0 + math.E)) > 1e-15 || c(2.0, 0) != 2.0 || c(2.0, 9) != 0.2 {
+        t.Fatalf(`cooling schedules = %f %f, %f %f, %f %f, want 2 0.0039, 2 %f, 2 0.2`, g(2.0, 0), g(2.0, 9), l(2.0, 0), l(2.0, 9), c(2.0, 0), c(2.0, 9), 2.0/math.Log(9.0 + math.E))
+    }
+}
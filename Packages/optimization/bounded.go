@@ -0,0 +1,253 @@
+package optimization
+
+import (
+    "errors"
+    "math"
+)
+
+// checkBounds validates the bounds against x0 and returns them with nil bounds replaced by infinities
+func checkBounds(x0 []float64, lower []float64, upper []float64) ([]float64, []float64, error) {
+    n := len(x0)
+    if lower == nil {
+        lower = make([]float64, n)
+        for i := range lower {
+            lower[i] = math.Inf(-1)
+        }
+    }
+    if upper == nil {
+        upper = make([]float64, n)
+        for i := range upper {
+            upper[i] = math.Inf(1)
+        }
+    }
+    if len(lower) != n || len(upper) != n {
+        return nil, nil, errors.New("lower and upper must have the same length as x0")
+    }
+    for i := range lower {
+        if lower[i] > upper[i] {
+            return nil, nil, errors.New("The following condition is not met: lower <= upper")
+        }
+    }
+    return lower, upper, nil
+}
+
+// project returns x clipped to the box [lower, upper]
+func project(x []float64, lower []float64, upper []float64) []float64 {
+    p := make([]float64, len(x))
+    for i := range x {
+        p[i] = math.Max(lower[i], math.Min(upper[i], x[i]))
+    }
+    return p
+}
+
+// projectedGradientNorm returns the largest absolute entry of P(x - g) - x, which is zero exactly at
+// points satisfying the first order conditions for the box
+func projectedGradientNorm(x []float64, g []float64, lower []float64, upper []float64) float64 {
+    m := 0.0
+    for i := range x {
+        m = math.Max(m, math.Abs(math.Max(lower[i], math.Min(upper[i], x[i] - g[i])) - x[i]))
+    }
+    return m
+}
+
+// projectedSearch backtracks along the projection arc P(x + alpha*d) until the Armijo condition
+// f(x(alpha)) <= f(x) + c1*g'(x(alpha) - x) holds
+func projectedSearch(f func([]float64) float64, x []float64, fx float64, g []float64, d []float64, lower []float64, upper []float64, alpha float64) (xnew []float64, fnew float64, ok bool) {
+    for k := 0; k < 60; k++ {
+        xnew = project(axpy(x, alpha, d), lower, upper)
+        decrease := 0.0
+        for i := range x {
+            decrease += g[i]*(xnew[i] - x[i])
+        }
+        if decrease >= 0.0 {
+            return nil, 0.0, false
+        }
+        fnew = f(xnew)
+        if fnew <= fx + 1e-4*decrease {
+            return xnew, fnew, true
+        }
+        alpha *= 0.5
+    }
+    return nil, 0.0, false
+}
+
+// ProjectedGradient (Projected gradient method for box constraints)
+// input:
+// the function to find the minimum for (f), the gradient of f (grad, nil uses central differences), initial guess (x0), lower bounds (lower, nil for none), upper bounds (upper, nil for none), projected gradient norm tolerance (es), maximum iterations (iter)
+// output:
+// the estimated x (x), function value (fx), largest absolute entry of the projected gradient (ea), iterations done (iter)
+func ProjectedGradient(f func([]float64) float64, grad func([]float64) []float64, x0 []float64, lower []float64, upper []float64, es float64, maxit int) (x []float64, fx float64, ea float64, iter int, err error) {
+    grad, _, err = checkMultivariate(f, grad, x0, nil, es)
+    if err != nil {
+        return nil, 0.0, 0.0, 0, err
+    }
+    lower, upper, err = checkBounds(x0, lower, upper)
+    if err != nil {
+        return nil, 0.0, 0.0, 0, err
+    }
+    x = project(x0, lower, upper)
+    fx = f(x)
+    g := grad(x)
+    ea = projectedGradientNorm(x, g, lower, upper)
+    d := make([]float64, len(x))
+    alpha := firstStep(g)
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        if ea <= es {
+            break
+        }
+        for i := range d {
+            d[i] = -g[i]
+        }
+        xnew, fnew, ok := projectedSearch(f, x, fx, g, d, lower, upper, 2.0*alpha)
+        if !ok {
+            return x, fx, ea, iter, errors.New("No step with sufficient decrease found")
+        }
+        s := make([]float64, len(x))
+        for i := range s {
+            s[i] = xnew[i] - x[i]
+        }
+        gnew := grad(xnew)
+        y := make([]float64, len(x))
+        for i := range y {
+            y[i] = gnew[i] - g[i]
+        }
+        if sy := dot(s, y); sy > 0.0 { // Barzilai-Borwein estimate of the next step length
+            alpha = dot(s, s)/sy
+        }
+        x, fx, g = xnew, fnew, gnew
+        ea = projectedGradientNorm(x, g, lower, upper)
+    }
+    return x, fx, ea, iter, nil
+}
+
+// LBFGSB (Limited-memory BFGS with bounds)
+// Variables at a bound whose gradient points out of the box are held fixed, an L-BFGS direction is computed in the
+// remaining free variables and a projected backtracking search keeps the iterates feasible
+// input:
+// the function to find the minimum for (f), the gradient of f (grad, nil uses central differences), initial guess (x0), lower bounds (lower, nil for none), upper bounds (upper, nil for none), number of stored pairs (m), projected gradient norm tolerance (es), maximum iterations (iter)
+// output:
+// the estimated x (x), function value (fx), largest absolute entry of the projected gradient (ea), iterations done (iter)
+func LBFGSB(f func([]float64) float64, grad func([]float64) []float64, x0 []float64, lower []float64, upper []float64, m int, es float64, maxit int) (x []float64, fx float64, ea float64, iter int, err error) {
+    grad, _, err = checkMultivariate(f, grad, x0, nil, es)
+    if err != nil {
+        return nil, 0.0, 0.0, 0, err
+    }
+    lower, upper, err = checkBounds(x0, lower, upper)
+    if err != nil {
+        return nil, 0.0, 0.0, 0, err
+    }
+    if m <= 0 {
+        return nil, 0.0, 0.0, 0, errors.New("m must be greater than 0")
+    }
+    n := len(x0)
+    var ss, ys [][]float64
+    x = project(x0, lower, upper)
+    fx = f(x)
+    g := grad(x)
+    ea = projectedGradientNorm(x, g, lower, upper)
+    free := make([]bool, n)
+    d := make([]float64, n)
+    a := make([]float64, m)
+    // masked dot product over the free variables
+    fdot := func(u []float64, v []float64) float64 {
+        s := 0.0
+        for i := range u {
+            if free[i] {
+                s += u[i]*v[i]
+            }
+        }
+        return s
+    }
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        if ea <= es {
+            break
+        }
+        const tol = 1e-10
+        for i := range free { // binding set: at a bound and the gradient pushes outwards
+            atlower := x[i] <= lower[i] + tol*math.Max(math.Abs(lower[i]), 1.0) && g[i] > 0.0
+            atupper := x[i] >= upper[i] - tol*math.Max(math.Abs(upper[i]), 1.0) && g[i] < 0.0
+            free[i] = !atlower && !atupper
+        }
+        for i := range d { // two-loop recursion restricted to the free variables
+            if free[i] {
+                d[i] = -g[i]
+            } else {
+                d[i] = 0.0
+            }
+        }
+        k := len(ss)
+        used := make([]bool, k)
+        for j := k - 1; j >= 0; j-- {
+            sy := fdot(ss[j], ys[j])
+            if sy <= 1e-12*math.Sqrt(fdot(ss[j], ss[j])*fdot(ys[j], ys[j])) {
+                continue
+            }
+            used[j] = true
+            a[j] = fdot(ss[j], d)/sy
+            for i := range d {
+                if free[i] {
+                    d[i] -= a[j]*ys[j][i]
+                }
+            }
+        }
+        for j := k - 1; j >= 0; j-- { // initial scaling from the newest usable pair
+            if used[j] {
+                gamma := fdot(ss[j], ys[j])/fdot(ys[j], ys[j])
+                for i := range d {
+                    d[i] *= gamma
+                }
+                break
+            }
+        }
+        for j := 0; j < k; j++ {
+            if !used[j] {
+                continue
+            }
+            b := fdot(ys[j], d)/fdot(ss[j], ys[j])
+            for i := range d {
+                if free[i] {
+                    d[i] += (a[j] - b)*ss[j][i]
+                }
+            }
+        }
+        alpha := 1.0
+        if dot(g, d) >= 0.0 || k == 0 { // restart from projected steepest descent
+            ss, ys = nil, nil
+            for i := range d {
+                if free[i] {
+                    d[i] = -g[i]
+                } else {
+                    d[i] = 0.0
+                }
+            }
+            alpha = firstStep(g)
+        }
+        xnew, fnew, ok := projectedSearch(f, x, fx, g, d, lower, upper, alpha)
+        if !ok {
+            if len(ss) == 0 {
+                return x, fx, ea, iter, errors.New("No step with sufficient decrease found")
+            }
+            ss, ys = nil, nil // the quasi-Newton direction failed, retry with steepest descent
+            continue
+        }
+        gnew := grad(xnew)
+        s := make([]float64, n)
+        y := make([]float64, n)
+        for i := range s {
+            s[i] = xnew[i] - x[i]
+            y[i] = gnew[i] - g[i]
+        }
+        if dot(s, y) > 1e-12*math.Sqrt(dot(s, s)*dot(y, y)) {
+            if len(ss) == m {
+                ss, ys = ss[1:], ys[1:]
+            }
+            ss = append(ss, s)
+            ys = append(ys, y)
+        }
+        x, fx, g = xnew, fnew, gnew
+        ea = projectedGradientNorm(x, g, lower, upper)
+    }
+    return x, fx, ea, iter, nil
+}
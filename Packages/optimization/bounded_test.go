@@ -0,0 +1,146 @@
+package optimization
+
+import (
+    "testing"
+    "math"
+)
+
+// TestProjectedGradient calls optimization.ProjectedGradient with the Rosenbrock function and minimizers on the
+// boundary, in the interior and with one sided bounds, checking that the returned point is feasible and matches the minimizer.
+func TestProjectedGradient(t *testing.T) {
+    f := func(x []float64) float64 { // extended Rosenbrock function, the minimum is at x = 1
+        s := 0.0
+        for i := 0; i + 1 < len(x); i += 2 {
+            s += (1.0 - x[i])*(1.0 - x[i]) + 100.0*(x[i+1] - x[i]*x[i])*(x[i+1] - x[i]*x[i])
+        }
+        return s
+    }
+    grad := func(x []float64) []float64 {
+        g := make([]float64, len(x))
+        for i := 0; i + 1 < len(x); i += 2 {
+            g[i] = -2.0*(1.0 - x[i]) - 400.0*x[i]*(x[i+1] - x[i]*x[i])
+            g[i+1] = 200.0*(x[i+1] - x[i]*x[i])
+        }
+        return g
+    }
+    inf := math.Inf(1)
+    problems := []struct {
+        name  string
+        grad  func([]float64) []float64
+        x0    []float64
+        lower []float64
+        upper []float64
+        xwant []float64
+    }{
+        {"upper bound", grad, []float64{-1.2, 1.0}, []float64{-2.0, -2.0}, []float64{0.5, 2.0}, []float64{0.5, 0.25}},
+        {"inactive bounds", grad, []float64{-1.2, 1.0}, []float64{-2.0, -2.0}, []float64{2.0, 2.0}, []float64{1.0, 1.0}},
+        {"lower bound", nil, []float64{2.0, 2.0}, []float64{1.5, -inf}, nil, []float64{1.5, 2.25}},
+        {"mixed bounds", grad, []float64{-1.2, 1.0, 2.0, 2.0}, []float64{-2.0, -inf, 1.5, -inf}, []float64{0.5, 2.0, inf, inf}, []float64{0.5, 0.25, 1.5, 2.25}},
+        {"unbounded", grad, []float64{-1.2, 1.0, -1.2, 1.0}, nil, nil, []float64{1.0, 1.0, 1.0, 1.0}},
+    }
+    for _, p := range problems {
+        x, fx, ea, iter, err := ProjectedGradient(f, p.grad, p.x0, p.lower, p.upper, 1e-7, 50000)
+        for i := range x {
+            if err != nil || math.Abs(x[i] - p.xwant[i]) > 1e-4 || (p.lower != nil && x[i] < p.lower[i]) || (p.upper != nil && x[i] > p.upper[i]) {
+                t.Fatalf(`ProjectedGradient(rosenbrock, %s) = %v, %g, %g, %d, %v, want %v`, p.name, x, fx, ea, iter, err, p.xwant)
+            }
+        }
+    }
+}
+
+// TestLBFGSB calls optimization.LBFGSB with the Rosenbrock function and minimizers on the boundary, in the interior
+// and with one sided bounds, checking that the returned point is feasible and matches the minimizer.
+func TestLBFGSB(t *testing.T) {
+    f := func(x []float64) float64 { // extended Rosenbrock function, the minimum is at x = 1
+        s := 0.0
+        for i := 0; i + 1 < len(x); i += 2 {
+            s += (1.0 - x[i])*(1.0 - x[i]) + 100.0*(x[i+1] - x[i]*x[i])*(x[i+1] - x[i]*x[i])
+        }
+        return s
+    }
+    grad := func(x []float64) []float64 {
+        g := make([]float64, len(x))
+        for i := 0; i + 1 < len(x); i += 2 {
+            g[i] = -2.0*(1.0 - x[i]) - 400.0*x[i]*(x[i+1] - x[i]*x[i])
+            g[i+1] = 200.0*(x[i+1] - x[i]*x[i])
+        }
+        return g
+    }
+    inf := math.Inf(1)
+    problems := []struct {
+        name  string
+        grad  func([]float64) []float64
+        x0    []float64
+        lower []float64
+        upper []float64
+        xwant []float64
+    }{
+        {"upper bound", grad, []float64{-1.2, 1.0}, []float64{-2.0, -2.0}, []float64{0.5, 2.0}, []float64{0.5, 0.25}},
+        {"inactive bounds", grad, []float64{-1.2, 1.0}, []float64{-2.0, -2.0}, []float64{2.0, 2.0}, []float64{1.0, 1.0}},
+        {"lower bound", nil, []float64{2.0, 2.0}, []float64{1.5, -inf}, nil, []float64{1.5, 2.25}},
+        {"mixed bounds", grad, []float64{-1.2, 1.0, 2.0, 2.0}, []float64{-2.0, -inf, 1.5, -inf}, []float64{0.5, 2.0, inf, inf}, []float64{0.5, 0.25, 1.5, 2.25}},
+        {"unbounded", grad, []float64{-1.2, 1.0, -1.2, 1.0}, nil, nil, []float64{1.0, 1.0, 1.0, 1.0}},
+    }
+    for _, p := range problems {
+        x, fx, ea, iter, err := LBFGSB(f, p.grad, p.x0, p.lower, p.upper, 5, 1e-8, 1000)
+        if err != nil || ea > 1e-8 || iter >= 1000 {
+            t.Fatalf(`LBFGSB(rosenbrock, %s) = %v, %g, %g, %d, %v, want %v`, p.name, x, fx, ea, iter, err, p.xwant)
+        }
+        for i := range x {
+            if math.Abs(x[i] - p.xwant[i]) > 1e-6 || (p.lower != nil && x[i] < p.lower[i]) || (p.upper != nil && x[i] > p.upper[i]) {
+                t.Fatalf(`LBFGSB(rosenbrock, %s) = %v, %g, %g, %d, %v, want %v`, p.name, x, fx, ea, iter, err, p.xwant)
+            }
+        }
+    }
+}
+
+// TestBoundsInvalid calls optimization.LBFGSB and optimization.ProjectedGradient with lower > upper, checking for an error.
+func TestBoundsInvalid(t *testing.T) {
+    f := func(x []float64) float64 { // extended Rosenbrock function, the minimum is at x = 1
+        s := 0.0
+        for i := 0; i + 1 < len(x); i += 2 {
+            s += (1.0 - x[i])*(1.0 - x[i]) + 100.0*(x[i+1] - x[i]*x[i])*(x[i+1] - x[i]*x[i])
+        }
+        return s
+    }
+    grad := func(x []float64) []float64 {
+        g := make([]float64, len(x))
+        for i := 0; i + 1 < len(x); i += 2 {
+            g[i] = -2.0*(1.0 - x[i]) - 400.0*x[i]*(x[i+1] - x[i]*x[i])
+            g[i+1] = 200.0*(x[i+1] - x[i]*x[i])
+        }
+        return g
+    }
+    x, fx, ea, iter, err := LBFGSB(f, grad, []float64{0.0, 0.0}, []float64{1.0, 0.0}, []float64{0.0, 1.0}, 5, 1e-6, 100)
+    if x != nil || fx != 0.0 || ea != 0.0 || iter != 0 || err == nil {
+        t.Fatalf(`LBFGSB(rosenbrock, grad, x0, [1, 0], [0, 1], 5, 1e-6, 100) = %v, %f, %f, %d, %v, want nil, 0, 0, 0, error`, x, fx, ea, iter, err)
+    }
+    x, fx, ea, iter, err = ProjectedGradient(f, grad, []float64{0.0, 0.0}, []float64{0.0}, nil, 1e-6, 100)
+    if x != nil || fx != 0.0 || ea != 0.0 || iter != 0 || err == nil {
+        t.Fatalf(`ProjectedGradient(rosenbrock, grad, x0, [0], nil, 1e-6, 100) = %v, %f, %f, %d, %v, want nil, 0, 0, 0, error`, x, fx, ea, iter, err)
+    }
+}
+
+// TestBoundsLineSearchFailure calls optimization.ProjectedGradient and optimization.LBFGSB with a function that is
+// undefined away from the initial guess, checking that the failed line search is returned as an error.
+func TestBoundsLineSearchFailure(t *testing.T) {
+    f := func(x []float64) float64 {
+        if x[0] != 1.0 || x[1] != 1.0 {
+            return math.NaN()
+        }
+        return 2.0
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{2.0*x[0], 2.0*x[1]}
+    }
+    lower := []float64{-2.0, -2.0}
+    upper := []float64{2.0, 2.0}
+    x, fx, ea, iter, err := ProjectedGradient(f, grad, []float64{1.0, 1.0}, lower, upper, 1e-6, 100)
+    if err == nil || x[0] != 1.0 || x[1] != 1.0 || fx != 2.0 || iter != 0 {
+        t.Fatalf(`ProjectedGradient(f, grad, [1, 1], [-2, -2], [2, 2], 1e-6, 100) = %v, %g, %g, %d, %v, want [1 1], 2, ea, 0, error`, x, fx, ea, iter, err)
+    }
+    x, fx, ea, iter, err = LBFGSB(f, grad, []float64{1.0, 1.0}, lower, upper, 5, 1e-6, 100)
+    if err == nil || x[0] != 1.0 || x[1] != 1.0 || fx != 2.0 || iter != 0 {
+        t.Fatalf(`LBFGSB(f, grad, [1, 1], [-2, -2], [2, 2], 5, 1e-6, 100) = %v, %g, %g, %d, %v, want [1 1], 2, ea, 0, error`, x, fx, ea, iter, err)
+    }
+}
@@ -0,0 +1,310 @@
+package optimization
+
+import (
+    "errors"
+    "math"
+    "math/rand"
+    "sort"
+)
+
+// CMARestart selects the restart strategy of CMA-ES
+type CMARestart int
+
+const (
+    // CMANoRestart runs CMA-ES once
+    CMANoRestart CMARestart = iota
+    // CMAIPOP doubles the population size at every restart (Auger and Hansen)
+    CMAIPOP
+    // CMABIPOP alternates between runs with doubling populations and runs with small populations and random step sizes,
+    // giving both regimes about the same number of function evaluations (Hansen)
+    CMABIPOP
+)
+
+// CMADiagnostics describes the search distribution N(Mean, Sigma^2 Covariance) at the end of the last CMA-ES run
+type CMADiagnostics struct {
+    Mean         []float64   // mean of the search distribution
+    Sigma        float64     // global step size
+    Covariance   [][]float64 // covariance matrix C of the search distribution, without the step size
+    Eigenvalues  []float64   // eigenvalues of C in increasing order
+    Eigenvectors [][]float64 // eigenvectors of C as columns, the principal axes of the search distribution
+    Condition    float64     // ratio of the largest and the smallest eigenvalue of C
+    Population   int         // population size of the last run
+    Restarts     int         // restarts done
+    Evaluations  int         // function evaluations over all runs
+}
+
+// reflect mirrors v into [lower, upper]
+func reflect(v float64, lower float64, upper float64) float64 {
+    width := upper - lower
+    v = math.Mod(v - lower, 2.0*width)
+    if v < 0.0 {
+        v += 2.0*width
+    }
+    if v > width {
+        v = 2.0*width - v
+    }
+    return lower + v
+}
+
+// cmaRun holds the state of a single CMA-ES run
+type cmaRun struct {
+    n, lambda, mu            int
+    weights                  []float64
+    mueff                    float64
+    cs, ds, cc, c1, cmu, chi float64
+    mean                     []float64
+    sigma                    float64
+    C, B                     [][]float64
+    D                        []float64
+    pc, ps                   []float64
+    gen                      int
+}
+
+// newCMARun initializes the strategy parameters of Hansen's tutorial for dimension n and population size lambda
+func newCMARun(mean []float64, sigma float64, lambda int) *cmaRun {
+    n := len(mean)
+    r := &cmaRun{n: n, lambda: lambda, mu: lambda/2, mean: append([]float64(nil), mean...), sigma: sigma}
+    r.weights = make([]float64, r.mu)
+    sw, sw2 := 0.0, 0.0
+    for i := range r.weights {
+        r.weights[i] = math.Log(float64(r.mu) + 0.5) - math.Log(float64(i + 1))
+        sw += r.weights[i]
+    }
+    for i := range r.weights {
+        r.weights[i] /= sw
+        sw2 += r.weights[i]*r.weights[i]
+    }
+    r.mueff = 1.0/sw2
+    nf := float64(n)
+    r.cs = (r.mueff + 2.0)/(nf + r.mueff + 5.0)
+    r.ds = 1.0 + 2.0*math.Max(0.0, math.Sqrt((r.mueff - 1.0)/(nf + 1.0)) - 1.0) + r.cs
+    r.cc = (4.0 + r.mueff/nf)/(nf + 4.0 + 2.0*r.mueff/nf)
+    r.c1 = 2.0/((nf + 1.3)*(nf + 1.3) + r.mueff)
+    r.cmu = math.Min(1.0 - r.c1, 2.0*(r.mueff - 2.0 + 1.0/r.mueff)/((nf + 2.0)*(nf + 2.0) + r.mueff))
+    r.chi = math.Sqrt(nf)*(1.0 - 1.0/(4.0*nf) + 1.0/(21.0*nf*nf))
+    r.C = make([][]float64, n)
+    r.B = make([][]float64, n)
+    r.D = make([]float64, n)
+    for i := 0; i < n; i++ {
+        r.C[i] = make([]float64, n)
+        r.B[i] = make([]float64, n)
+        r.C[i][i], r.B[i][i], r.D[i] = 1.0, 1.0, 1.0
+    }
+    r.pc = make([]float64, n)
+    r.ps = make([]float64, n)
+    return r
+}
+
+// update moves the distribution towards the mu best of the evaluated points, sorted by their function values
+func (r *cmaRun) update(points [][]float64) {
+    n := r.n
+    old := r.mean
+    r.mean = make([]float64, n)
+    ys := make([][]float64, r.mu)
+    for k := 0; k < r.mu; k++ {
+        ys[k] = make([]float64, n)
+        for i := 0; i < n; i++ {
+            r.mean[i] += r.weights[k]*points[k][i]
+            ys[k][i] = (points[k][i] - old[i])/r.sigma
+        }
+    }
+    yw := make([]float64, n)
+    for i := range yw {
+        yw[i] = (r.mean[i] - old[i])/r.sigma
+    }
+    // C^(-1/2) yw = B D^-1 B' yw
+    bty := make([]float64, n)
+    for j := 0; j < n; j++ {
+        for i := 0; i < n; i++ {
+            bty[j] += r.B[i][j]*yw[i]
+        }
+        bty[j] /= r.D[j]
+    }
+    cs := math.Sqrt(r.cs*(2.0 - r.cs)*r.mueff)
+    for i := 0; i < n; i++ {
+        s := 0.0
+        for j := 0; j < n; j++ {
+            s += r.B[i][j]*bty[j]
+        }
+        r.ps[i] = (1.0 - r.cs)*r.ps[i] + cs*s
+    }
+    r.gen++
+    psnorm := math.Sqrt(dot(r.ps, r.ps))
+    hs := 0.0
+    if psnorm/math.Sqrt(1.0 - math.Pow(1.0 - r.cs, 2.0*float64(r.gen))) < (1.4 + 2.0/(float64(n) + 1.0))*r.chi {
+        hs = 1.0
+    }
+    cc := math.Sqrt(r.cc*(2.0 - r.cc)*r.mueff)
+    for i := range r.pc {
+        r.pc[i] = (1.0 - r.cc)*r.pc[i] + hs*cc*yw[i]
+    }
+    decay := 1.0 - r.c1 - r.cmu + (1.0 - hs)*r.c1*r.cc*(2.0 - r.cc)
+    for i := 0; i < n; i++ {
+        for j := 0; j <= i; j++ {
+            rankmu := 0.0
+            for k := 0; k < r.mu; k++ {
+                rankmu += r.weights[k]*ys[k][i]*ys[k][j]
+            }
+            v := decay*r.C[i][j] + r.c1*r.pc[i]*r.pc[j] + r.cmu*rankmu
+            r.C[i][j], r.C[j][i] = v, v
+        }
+    }
+    r.sigma *= math.Exp((r.cs/r.ds)*(psnorm/r.chi - 1.0))
+    values, B := symmetricEigen(r.C)
+    r.B = B
+    for i := range values {
+        r.D[i] = math.Sqrt(math.Max(values[i], 1e-300))
+    }
+}
+
+// CMAES (Covariance matrix adaptation evolution strategy)
+// Samples lambda points per generation from N(m, sigma^2 C) and adapts m, sigma and C from the best half. A run stops
+// when the largest standard deviation sigma*sqrt(max eig C) or the range of the recent best function values drops to
+// es, or when C becomes ill-conditioned, after which up to restarts new runs are started with the restart strategy,
+// from a uniform random point when bounds are given and from x0 otherwise. Samples outside the bounds are mirrored into
+// the box. The samples are drawn from the seeded random number generator before they are evaluated by up to workers
+// goroutines, so the result does not depend on workers.
+// input:
+// the function to find the minimum for (f), initial mean (x0), initial step size (sigma0), lower bounds (lower, nil for none), upper bounds (upper, nil for none), population size (lambda, 0 uses 4 + 3 ln n), restart strategy (restart), maximum restarts (restarts), seed of the random number generator (seed), number of goroutines evaluating f (workers), tolerance for the step size and the function values (es), maximum generations over all runs (iter)
+// output:
+// the best x (x), function value (fx), search distribution of the last run (diag), largest standard deviation of the last run (ea), generations done (iter)
+func CMAES(f func([]float64) float64, x0 []float64, sigma0 float64, lower []float64, upper []float64, lambda int, restart CMARestart, restarts int, seed int64, workers int, es float64, maxit int) (x []float64, fx float64, diag CMADiagnostics, ea float64, iter int, err error) {
+    if len(x0) == 0 {
+        return nil, 0.0, diag, 0.0, 0, errors.New("x0 must not be empty")
+    }
+    if sigma0 <= 0.0 {
+        return nil, 0.0, diag, 0.0, 0, errors.New("sigma0 must be greater than 0")
+    }
+    if es <= 0.0 {
+        return nil, 0.0, diag, 0.0, 0, errors.New("es must be greater than 0")
+    }
+    if restart < CMANoRestart || restart > CMABIPOP {
+        return nil, 0.0, diag, 0.0, 0, errors.New("Unknown CMA-ES restart strategy")
+    }
+    if restarts < 0 {
+        return nil, 0.0, diag, 0.0, 0, errors.New("restarts must not be negative")
+    }
+    bounded := lower != nil || upper != nil
+    if bounded {
+        if err = checkBox(lower, upper); err != nil {
+            return nil, 0.0, diag, 0.0, 0, err
+        }
+        if len(lower) != len(x0) {
+            return nil, 0.0, diag, 0.0, 0, errors.New("lower and upper must have the length of x0")
+        }
+    }
+    n := len(x0)
+    if lambda <= 0 {
+        lambda = 4 + int(3.0*math.Log(float64(n)))
+    }
+    if lambda < 4 {
+        lambda = 4
+    }
+    rng := rand.New(rand.NewSource(seed))
+    fx = math.Inf(1)
+    large := lambda // population of the large regime
+    evalsLarge, evalsSmall := 0, 0
+    start := append([]float64(nil), x0...)
+    if bounded {
+        for i := range start {
+            start[i] = reflect(start[i], lower[i], upper[i])
+        }
+    }
+    runLambda, runSigma := lambda, sigma0
+    smallRun := false
+    var r *cmaRun
+    iter = 0
+    for run := 0; run <= restarts; run++ {
+        if run > 0 {
+            if restart == CMANoRestart || iter >= maxit {
+                break
+            }
+            diag.Restarts++
+            if bounded {
+                for i := range start {
+                    start[i] = lower[i] + rng.Float64()*(upper[i] - lower[i])
+                }
+            }
+            smallRun = restart == CMABIPOP && evalsSmall < evalsLarge
+            if smallRun { // small population with a random step size
+                u := rng.Float64()
+                runLambda = int(float64(lambda)*math.Pow(0.5*float64(large)/float64(lambda), u*u))
+                if runLambda < 4 {
+                    runLambda = 4
+                }
+                runSigma = sigma0*math.Pow(10.0, -2.0*rng.Float64())
+            } else {
+                large *= 2
+                runLambda, runSigma = large, sigma0
+            }
+        }
+        r = newCMARun(start, runSigma, runLambda)
+        history := make([]float64, 0)
+        window := 10 + int(math.Ceil(30.0*float64(n)/float64(runLambda)))
+        for ; iter < maxit; iter ++ {
+            points := make([][]float64, runLambda)
+            for k := range points {
+                z := make([]float64, n)
+                for j := range z {
+                    z[j] = r.D[j]*rng.NormFloat64()
+                }
+                points[k] = make([]float64, n)
+                for i := 0; i < n; i++ {
+                    s := 0.0
+                    for j := 0; j < n; j++ {
+                        s += r.B[i][j]*z[j]
+                    }
+                    points[k][i] = r.mean[i] + r.sigma*s
+                    if bounded {
+                        points[k][i] = reflect(points[k][i], lower[i], upper[i])
+                    }
+                }
+            }
+            fpoints := evaluate(f, points, workers)
+            diag.Evaluations += runLambda
+            if smallRun {
+                evalsSmall += runLambda
+            } else {
+                evalsLarge += runLambda
+            }
+            order := make([]int, runLambda)
+            for k := range order {
+                order[k] = k
+            }
+            sort.SliceStable(order, func(a, b int) bool { return fpoints[order[a]] < fpoints[order[b]] })
+            sorted := make([][]float64, runLambda)
+            for k, o := range order {
+                sorted[k] = points[o]
+            }
+            if fpoints[order[0]] < fx {
+                x, fx = append([]float64(nil), sorted[0]...), fpoints[order[0]]
+            }
+            r.update(sorted)
+            ea = r.sigma*r.D[n-1]
+            // Stopping criteria of the run
+            history = append(history, fpoints[order[0]])
+            if len(history) > window {
+                history = history[1:]
+            }
+            frange := fpoints[order[runLambda-1]] - fpoints[order[0]]
+            for _, v := range history {
+                frange = math.Max(frange, math.Abs(v - fpoints[order[0]]))
+            }
+            if ea <= es || (len(history) == window && frange <= es) || r.D[n-1] > 1e7*r.D[0] {
+                iter++
+                break
+            }
+        }
+    }
+    diag.Mean = r.mean
+    diag.Sigma = r.sigma
+    diag.Covariance = r.C
+    diag.Eigenvectors = r.B
+    diag.Eigenvalues = make([]float64, n)
+    for i := range r.D {
+        diag.Eigenvalues[i] = r.D[i]*r.D[i]
+    }
+    diag.Condition = diag.Eigenvalues[n-1]/diag.Eigenvalues[0]
+    diag.Population = r.lambda
+    return x, fx, diag, ea, iter, nil
+}
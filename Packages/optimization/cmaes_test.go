@@ -0,0 +1,123 @@
+package optimization
+
+import (
+    "testing"
+    "fmt"
+    "math"
+)
+
+// TestCMAES calls optimization.CMAES with a ten dimensional ellipsoid with condition number 10^6,
+// checking for the minimum and for a covariance matrix that learned the conditioning.
+func TestCMAES(t *testing.T) {
+    ellipsoid := func(x []float64) float64 { // sum 10^(6 i/(n - 1)) x_i^2, condition number 10^6
+        s := 0.0
+        for i, v := range x {
+            s += math.Pow(1e6, float64(i)/float64(len(x) - 1))*v*v
+        }
+        return s
+    }
+    x0 := make([]float64, 10)
+    for i := range x0 {
+        x0[i] = 1.0
+    }
+    es := 1e-10
+    maxit := 5000
+    x, fx, diag, ea, iter, err := CMAES(ellipsoid, x0, 0.5, nil, nil, 0, CMANoRestart, 0, 1, 1, es, maxit)
+    msg := fmt.Sprintf("%v, %g, %g, %g, %d", x, fx, diag.Condition, ea, iter)
+    want := fmt.Sprintf("[0 ... 0], 0, 1e6, %g, %d < %d", es, iter, maxit)
+    if err != nil || norminf(x) > 1e-5 || fx > 1e-10 || diag.Condition < 1e5 || diag.Condition > 1e7 || diag.Population != 10 || diag.Restarts != 0 || ea > 1e-6 || iter >= maxit {
+        t.Fatalf(`CMAES(ellipsoid, [1 ... 1], 0.5, nil, nil, 0, CMANoRestart, 0, 1, 1, 1e-10, 5000) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestCMAESRosenbrock calls optimization.CMAES with the four dimensional Rosenbrock function,
+// checking for the minimum at (1, 1, 1, 1).
+func TestCMAESRosenbrock(t *testing.T) {
+    f := func(x []float64) float64 { // extended Rosenbrock function, the minimum is at x = 1
+        s := 0.0
+        for i := 0; i + 1 < len(x); i += 2 {
+            s += (1.0 - x[i])*(1.0 - x[i]) + 100.0*(x[i+1] - x[i]*x[i])*(x[i+1] - x[i]*x[i])
+        }
+        return s
+    }
+    x, fx, diag, ea, iter, err := CMAES(f, []float64{-1.2, 1.0, -1.2, 1.0}, 0.5, nil, nil, 0, CMANoRestart, 0, 1, 1, 1e-10, 5000)
+    msg := fmt.Sprintf("%v, %g, %d, %g, %d", x, fx, diag.Evaluations, ea, iter)
+    want := "[1 1 1 1], 0, evaluations, ea, iter"
+    if err != nil || math.Abs(x[0] - 1.0) > 1e-5 || math.Abs(x[3] - 1.0) > 1e-5 || fx > 1e-10 {
+        t.Fatalf(`CMAES(rosenbrock, [-1.2 1 -1.2 1], 0.5, nil, nil, 0, CMANoRestart, 0, 1, 1, 1e-10, 5000) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestCMAESRestarts calls optimization.CMAES with the Rastrigin function in a box for the IPOP and BIPOP restart
+// strategies, checking for the global minimum.
+func TestCMAESRestarts(t *testing.T) {
+    rastrigin := func(x []float64) float64 { // global minimum 0 at the origin surrounded by a grid of local minima
+        s := 10.0*float64(len(x))
+        for _, v := range x {
+            s += v*v - 10.0*math.Cos(2.0*math.Pi*v)
+        }
+        return s
+    }
+    cases := []struct {
+        restart CMARestart
+        n       int
+        seed    int64
+    }{{CMAIPOP, 5, 1}, {CMABIPOP, 3, 2}}
+    for _, c := range cases {
+        lower := make([]float64, c.n)
+        upper := make([]float64, c.n)
+        x0 := make([]float64, c.n)
+        for i := range x0 {
+            lower[i], upper[i], x0[i] = -5.12, 5.12, 3.0
+        }
+        x, fx, diag, ea, iter, err := CMAES(rastrigin, x0, 2.0, lower, upper, 0, c.restart, 8, c.seed, 1, 1e-10, 20000)
+        msg := fmt.Sprintf("%v, %g, %d restarts, %g, %d", x, fx, diag.Restarts, ea, iter)
+        want := "[0 ... 0], 0, restarts, ea, iter"
+        if err != nil || norminf(x) > 1e-6 || fx > 1e-10 || diag.Restarts == 0 {
+            t.Fatalf(`CMAES(rastrigin, [3 ... 3], 2, lower, upper, 0, %d, 8, %d, 1, 1e-10, 20000) = %q, %v, want match for %#v, nil`, c.restart, c.seed, msg, err, want)
+        }
+    }
+}
+
+// TestCMAESWorkers calls optimization.CMAES with one and with four workers,
+// checking that the results are identical.
+func TestCMAESWorkers(t *testing.T) {
+    rastrigin := func(x []float64) float64 { // global minimum 0 at the origin surrounded by a grid of local minima
+        s := 10.0*float64(len(x))
+        for _, v := range x {
+            s += v*v - 10.0*math.Cos(2.0*math.Pi*v)
+        }
+        return s
+    }
+    lower := []float64{-5.12, -5.12, -5.12}
+    upper := []float64{5.12, 5.12, 5.12}
+    x1, fx1, d1, ea1, iter1, err1 := CMAES(rastrigin, []float64{1.0, 2.0, 3.0}, 1.0, lower, upper, 0, CMAIPOP, 2, 5, 1, 1e-8, 500)
+    x4, fx4, d4, ea4, iter4, err4 := CMAES(rastrigin, []float64{1.0, 2.0, 3.0}, 1.0, lower, upper, 0, CMAIPOP, 2, 5, 4, 1e-8, 500)
+    if err1 != nil || err4 != nil || fmt.Sprint(x1) != fmt.Sprint(x4) || fx1 != fx4 || d1.Evaluations != d4.Evaluations || ea1 != ea4 || iter1 != iter4 {
+        t.Fatalf(`CMAES with 4 workers = %v, %g, %g, %d, %v, want %v, %g, %g, %d, %v`, x4, fx4, ea4, iter4, err4, x1, fx1, ea1, iter1, err1)
+    }
+}
+
+// TestCMAESErrors calls optimization.CMAES with an empty initial guess, a nonpositive step size, an unknown restart
+// strategy and a negative number of restarts, checking for errors.
+func TestCMAESErrors(t *testing.T) {
+    rastrigin := func(x []float64) float64 { // global minimum 0 at the origin surrounded by a grid of local minima
+        s := 10.0*float64(len(x))
+        for _, v := range x {
+            s += v*v - 10.0*math.Cos(2.0*math.Pi*v)
+        }
+        return s
+    }
+    calls := []struct {
+        x0       []float64
+        sigma0   float64
+        restart  CMARestart
+        restarts int
+    }{{nil, 1.0, CMANoRestart, 0}, {[]float64{1.0}, 0.0, CMANoRestart, 0}, {[]float64{1.0}, 1.0, CMARestart(7), 0}, {[]float64{1.0}, 1.0, CMAIPOP, -1}}
+    for _, c := range calls {
+        x, fx, _, ea, iter, err := CMAES(rastrigin, c.x0, c.sigma0, nil, nil, 0, c.restart, c.restarts, 1, 1, 1e-8, 10)
+        if x != nil || fx != 0.0 || ea != 0.0 || iter != 0 || err == nil {
+            t.Fatalf(`CMAES(rastrigin, %v, %g, nil, nil, 0, %d, %d, 1, 1, 1e-8, 10) = %v, %g, %g, %d, %v, want nil, 0, 0, 0, error`, c.x0, c.sigma0, c.restart, c.restarts, x, fx, ea, iter, err)
+        }
+    }
+}
@@ -0,0 +1,127 @@
+package optimization
+
+import (
+    "errors"
+    "math"
+)
+
+// CGVariant selects the formula for the conjugate gradient parameter beta
+type CGVariant int
+
+const (
+    // CGFletcherReeves uses beta = g'g/g0'g0
+    CGFletcherReeves CGVariant = iota
+    // CGPolakRibierePlus uses beta = max(0, g'y/g0'g0)
+    CGPolakRibierePlus
+    // CGHestenesStiefel uses beta = g'y/d'y
+    CGHestenesStiefel
+    // CGDaiYuan uses beta = g'g/d'y
+    CGDaiYuan
+    // CGHagerZhang uses beta = (y - 2d|y|^2/d'y)'g/d'y with the lower bound of Hager and Zhang
+    CGHagerZhang
+)
+
+// ConjugateGradient (Nonlinear conjugate gradient method)
+// The direction is reset to steepest descent every n iterations, when successive gradients are far from
+// orthogonal (|g'g0| >= 0.2*g'g) or when the new direction is not a descent direction
+// input:
+// the function to find the minimum for (f), the gradient of f (grad, nil uses central differences), initial guess (x0), formula for beta (variant), line search (ls, nil uses strong Wolfe with c2 = 0.1), gradient norm tolerance (es), maximum iterations (iter)
+// output:
+// the estimated x (x), function value (fx), largest absolute gradient entry (ea), iterations done (iter)
+func ConjugateGradient(f func([]float64) float64, grad func([]float64) []float64, x0 []float64, variant CGVariant, ls LineSearch, es float64, maxit int) (x []float64, fx float64, ea float64, iter int, err error) {
+    if ls == nil {
+        ls = MoreThuente(1e-4, 0.1)
+    }
+    grad, ls, err = checkMultivariate(f, grad, x0, ls, es)
+    if err != nil {
+        return nil, 0.0, 0.0, 0, err
+    }
+    if variant < CGFletcherReeves || variant > CGHagerZhang {
+        return nil, 0.0, 0.0, 0, errors.New("Unknown conjugate gradient variant")
+    }
+    n := len(x0)
+    x = append([]float64(nil), x0...)
+    fx = f(x)
+    g := grad(x)
+    ea = norminf(g)
+    d := make([]float64, n)
+    y := make([]float64, n)
+    for i := range d {
+        d[i] = -g[i]
+    }
+    slope := dot(g, d)
+    alpha0 := firstStep(g)
+    sincerestart := 0
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        if ea <= es {
+            break
+        }
+        phi, dphi, last := lineFunctions(f, grad, x, d)
+        alpha, fnew, lserr := ls(phi, dphi, fx, slope, alpha0)
+        if lserr != nil {
+            if sincerestart == 0 { // already a steepest descent step, no further progress possible
+                return x, fx, ea, iter, lserr
+            }
+            for i := range d {
+                d[i] = -g[i]
+            }
+            slope = dot(g, d)
+            alpha0 = firstStep(g)
+            sincerestart = 0
+            continue
+        }
+        xnew := axpy(x, alpha, d)
+        gnew := last(alpha)
+        for i := range y {
+            y[i] = gnew[i] - g[i]
+        }
+        gg := dot(g, g)
+        gnewgnew := dot(gnew, gnew)
+        dy := dot(d, y)
+        var beta float64
+        switch variant {
+        case CGFletcherReeves:
+            beta = gnewgnew/gg
+        case CGPolakRibierePlus:
+            beta = math.Max(0.0, dot(gnew, y)/gg)
+        case CGHestenesStiefel:
+            beta = dot(gnew, y)/dy
+        case CGDaiYuan:
+            beta = gnewgnew/dy
+        case CGHagerZhang:
+            yy := dot(y, y)
+            beta = (dot(y, gnew) - 2.0*yy*dot(d, gnew)/dy)/dy
+            eta := -1.0/(math.Sqrt(dot(d, d))*math.Min(0.01, math.Sqrt(gg)))
+            beta = math.Max(beta, eta)
+        }
+        sincerestart++
+        restart := sincerestart >= n || math.Abs(dot(gnew, g)) >= 0.2*gnewgnew || math.IsNaN(beta) || math.IsInf(beta, 0)
+        oldslope := slope
+        for i := range d {
+            if restart {
+                d[i] = -gnew[i]
+            } else {
+                d[i] = -gnew[i] + beta*d[i]
+            }
+        }
+        slope = dot(gnew, d)
+        if slope >= 0.0 { // not a descent direction
+            restart = true
+            for i := range d {
+                d[i] = -gnew[i]
+            }
+            slope = dot(gnew, d)
+        }
+        if restart {
+            sincerestart = 0
+        }
+        alpha0 = alpha*oldslope/slope // assume the same first order change as in the last step
+        if alpha0 <= 0.0 || math.IsNaN(alpha0) || math.IsInf(alpha0, 0) {
+            alpha0 = firstStep(gnew)
+        }
+        x, fx, g = xnew, fnew, gnew
+        ea = norminf(g)
+    }
+    return x, fx, ea, iter, nil
+}
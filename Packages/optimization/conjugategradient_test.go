@@ -0,0 +1,115 @@
+package optimization
+
+import (
+    "testing"
+    "errors"
+    "math"
+)
+
+// TestConjugateGradient calls optimization.ConjugateGradient with every variant on the extended Rosenbrock
+// function in 100 dimensions, checking for a valid return value.
+func TestConjugateGradient(t *testing.T) {
+    f := func(x []float64) float64 { // extended Rosenbrock function, the minimum is at x = 1
+        s := 0.0
+        for i := 0; i + 1 < len(x); i += 2 {
+            s += (1.0 - x[i])*(1.0 - x[i]) + 100.0*(x[i+1] - x[i]*x[i])*(x[i+1] - x[i]*x[i])
+        }
+        return s
+    }
+    grad := func(x []float64) []float64 {
+        g := make([]float64, len(x))
+        for i := 0; i + 1 < len(x); i += 2 {
+            g[i] = -2.0*(1.0 - x[i]) - 400.0*x[i]*(x[i+1] - x[i]*x[i])
+            g[i+1] = 200.0*(x[i+1] - x[i]*x[i])
+        }
+        return g
+    }
+    x0 := make([]float64, 100)
+    for i := range x0 {
+        x0[i] = 1.0
+        if i % 2 == 0 {
+            x0[i] = -1.2
+        }
+    }
+    es := 1e-6
+    maxit := 5000
+    variants := map[string]CGVariant{
+        "CGFletcherReeves": CGFletcherReeves,
+        "CGPolakRibierePlus": CGPolakRibierePlus,
+        "CGHestenesStiefel": CGHestenesStiefel,
+        "CGDaiYuan": CGDaiYuan,
+        "CGHagerZhang": CGHagerZhang,
+    }
+    for name, variant := range variants {
+        x, fx, ea, iter, err := ConjugateGradient(f, grad, x0, variant, nil, es, maxit)
+        if err != nil || ea > es || iter >= maxit {
+            t.Fatalf(`ConjugateGradient(rosenbrock, grad, x0, %s, nil, 1e-6, 5000) = %g, %g, %d, %v, want 0, < %g, < %d, nil`, name, fx, ea, iter, err, es, maxit)
+        }
+        for i := range x {
+            if math.Abs(x[i] - 1.0) > 1e-5 {
+                t.Fatalf(`ConjugateGradient(rosenbrock, grad, x0, %s, nil, 1e-6, 5000) x[%d] = %f, want 1`, name, i, x[i])
+            }
+        }
+    }
+}
+
+// TestConjugateGradientQuadratic calls optimization.ConjugateGradient on a convex quadratic with a Hager-Zhang
+// line search, checking that the minimum is found in a few iterations.
+func TestConjugateGradientQuadratic(t *testing.T) {
+    n := 20
+    f := func(x []float64) float64 {
+        s := 0.0
+        for i, v := range x {
+            s += 0.5*float64(i + 1)*v*v - v
+        }
+        return s
+    }
+    grad := func(x []float64) []float64 {
+        g := make([]float64, len(x))
+        for i, v := range x {
+            g[i] = float64(i + 1)*v - 1.0
+        }
+        return g
+    }
+    x, fx, ea, iter, err := ConjugateGradient(f, grad, make([]float64, n), CGPolakRibierePlus, HagerZhang(0.1, 0.9), 1e-8, 200)
+    if err != nil || ea > 1e-8 || iter >= 200 {
+        t.Fatalf(`ConjugateGradient(f, grad, zeros(20), CGPolakRibierePlus, HagerZhang, 1e-8, 200) = %v, %g, %g, %d, %v`, x, fx, ea, iter, err)
+    }
+    for i := range x {
+        if math.Abs(x[i] - 1.0/float64(i + 1)) > 1e-7 {
+            t.Fatalf(`ConjugateGradient(f, grad, zeros(20), CGPolakRibierePlus, HagerZhang, 1e-8, 200) x[%d] = %f, want %f`, i, x[i], 1.0/float64(i + 1))
+        }
+    }
+}
+
+// TestConjugateGradientVariant calls optimization.ConjugateGradient with an unknown variant, checking for an error.
+func TestConjugateGradientVariant(t *testing.T) {
+    f := func(x []float64) float64 {
+        return (1.0 - x[0])*(1.0 - x[0]) + 100.0*(x[1] - x[0]*x[0])*(x[1] - x[0]*x[0])
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{-2.0*(1.0 - x[0]) - 400.0*x[0]*(x[1] - x[0]*x[0]), 200.0*(x[1] - x[0]*x[0])}
+    }
+    x, fx, ea, iter, err := ConjugateGradient(f, grad, []float64{-1.2, 1.0}, CGVariant(42), nil, 1e-6, 100)
+    if x != nil || fx != 0.0 || ea != 0.0 || iter != 0 || err == nil {
+        t.Fatalf(`ConjugateGradient(rosenbrock, grad, x0, 42, nil, 1e-6, 100) = %v, %f, %f, %d, %v, want nil, 0, 0, 0, error`, x, fx, ea, iter, err)
+    }
+}
+
+// TestConjugateGradientLineSearchFailure calls optimization.ConjugateGradient with a line search that always fails,
+// checking that the failure of the steepest descent restart is returned as an error.
+func TestConjugateGradientLineSearchFailure(t *testing.T) {
+    f := func(x []float64) float64 {
+        return (1.0 - x[0])*(1.0 - x[0]) + 100.0*(x[1] - x[0]*x[0])*(x[1] - x[0]*x[0])
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{-2.0*(1.0 - x[0]) - 400.0*x[0]*(x[1] - x[0]*x[0]), 200.0*(x[1] - x[0]*x[0])}
+    }
+    fail := func(phi func(float64) float64, dphi func(float64) float64, phi0 float64, dphi0 float64, alpha0 float64) (float64, float64, error) {
+        return 0.0, 0.0, errors.New("no step")
+    }
+    x, fx, ea, iter, err := ConjugateGradient(f, grad, []float64{-1.2, 1.0}, CGPolakRibierePlus, fail, 1e-6, 100)
+    if err == nil {
+        t.Fatalf(`ConjugateGradient(rosenbrock, grad, x0, CGPolakRibierePlus, fail, 1e-6, 100) = %v, %g, %g, %d, nil, want error`, x, fx, ea, iter)
+    }
+}
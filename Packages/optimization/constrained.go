@@ -0,0 +1,272 @@
+package optimization
+
+import (
+    "errors"
+    "math"
+)
+
+// constraintFunctions replaces missing constraints by empty ones and missing Jacobians by finite differences
+func constraintFunctions(c func([]float64) []float64, cjac func([]float64) [][]float64) (func([]float64) []float64, func([]float64) [][]float64) {
+    if c == nil {
+        return func(x []float64) []float64 { return nil }, func(x []float64) [][]float64 { return nil }
+    }
+    if cjac == nil {
+        cjac = fdJacobian(c)
+    }
+    return c, cjac
+}
+
+// lagrangianGradient returns grad f + Jg'lambda + Jh'mu
+func lagrangianGradient(gf []float64, Jg [][]float64, lambda []float64, Jh [][]float64, mu []float64) []float64 {
+    gl := append([]float64(nil), gf...)
+    for i, row := range Jg {
+        for j := range gl {
+            gl[j] += lambda[i]*row[j]
+        }
+    }
+    for i, row := range Jh {
+        for j := range gl {
+            gl[j] += mu[i]*row[j]
+        }
+    }
+    return gl
+}
+
+// kktResidual returns the largest violation of stationarity, primal feasibility and complementarity
+func kktResidual(gf []float64, gx []float64, Jg [][]float64, lambda []float64, hx []float64, Jh [][]float64, mu []float64) float64 {
+    r := norminf(lagrangianGradient(gf, Jg, lambda, Jh, mu))
+    for i := range gx {
+        r = math.Max(r, math.Max(gx[i], 0.0))
+        r = math.Max(r, math.Abs(lambda[i]*gx[i]))
+    }
+    return math.Max(r, norminf(hx))
+}
+
+// Minimizer is an unconstrained gradient based minimizer, GradientDescent fits as it is and BFGS, LBFGS,
+// ConjugateGradient or Newton fit with a closure fixing their remaining arguments
+type Minimizer func(f func([]float64) float64, grad func([]float64) []float64, x0 []float64, es float64, maxit int) (x []float64, fx float64, ea float64, iter int, err error)
+
+// AugmentedLagrangian (Augmented Lagrangian method for nonlinear constraints)
+// Minimizes f subject to g(x) <= 0 and h(x) = 0 by minimizing the augmented Lagrangian with the inner minimizer,
+// updating the multipliers after each inner solve and increasing the penalty when the constraint violation does not shrink
+// input:
+// the function to find the minimum for (f), the gradient of f (grad, nil uses central differences), inequality constraints (g, nil for none), Jacobian of g (gjac, nil uses central differences), equality constraints (h, nil for none), Jacobian of h (hjac, nil uses central differences), minimizer of the augmented Lagrangian (inner, nil uses LBFGS with 10 pairs), initial guess (x0), KKT residual tolerance (es), maximum outer iterations (iter)
+// output:
+// the estimated x (x), function value (fx), multipliers of g (lambda), multipliers of h (mu), KKT residual (ea), outer iterations done (iter)
+func AugmentedLagrangian(f func([]float64) float64, grad func([]float64) []float64, g func([]float64) []float64, gjac func([]float64) [][]float64, h func([]float64) []float64, hjac func([]float64) [][]float64, inner Minimizer, x0 []float64, es float64, maxit int) (x []float64, fx float64, lambda []float64, mu []float64, ea float64, iter int, err error) {
+    grad, _, err = checkMultivariate(f, grad, x0, nil, es)
+    if err != nil {
+        return nil, 0.0, nil, nil, 0.0, 0, err
+    }
+    if inner == nil {
+        inner = func(f func([]float64) float64, grad func([]float64) []float64, x0 []float64, es float64, maxit int) ([]float64, float64, float64, int, error) {
+            return LBFGS(f, grad, x0, 10, nil, es, maxit)
+        }
+    }
+    g, gjac = constraintFunctions(g, gjac)
+    h, hjac = constraintFunctions(h, hjac)
+    x = append([]float64(nil), x0...)
+    gx, hx := g(x), h(x)
+    lambda = make([]float64, len(gx))
+    mu = make([]float64, len(hx))
+    rho := 10.0
+    violation := func(gx []float64, hx []float64) float64 {
+        v := norminf(hx)
+        for i := range gx {
+            v = math.Max(v, math.Max(gx[i], -lambda[i]/rho))
+        }
+        return v
+    }
+    oldviolation := violation(gx, hx)
+    ea = kktResidual(grad(x), gx, gjac(x), lambda, hx, hjac(x), mu)
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        if ea <= es {
+            break
+        }
+        la := func(x []float64) float64 { // augmented Lagrangian
+            s := f(x)
+            for i, v := range h(x) {
+                s += mu[i]*v + 0.5*rho*v*v
+            }
+            for i, v := range g(x) {
+                t := math.Max(0.0, lambda[i] + rho*v)
+                s += (t*t - lambda[i]*lambda[i])/(2.0*rho)
+            }
+            return s
+        }
+        lagrad := func(x []float64) []float64 {
+            gv, hv := g(x), h(x)
+            lm := make([]float64, len(gv))
+            for i, v := range gv {
+                lm[i] = math.Max(0.0, lambda[i] + rho*v)
+            }
+            mm := make([]float64, len(hv))
+            for i, v := range hv {
+                mm[i] = mu[i] + rho*v
+            }
+            return lagrangianGradient(grad(x), gjac(x), lm, hjac(x), mm)
+        }
+        xnew, _, _, _, ierr := inner(la, lagrad, x, math.Max(0.1*ea, 0.1*es), 500)
+        if ierr != nil {
+            return x, f(x), lambda, mu, ea, iter, ierr
+        }
+        x = xnew
+        gx, hx = g(x), h(x)
+        for i, v := range gx { // first order multiplier updates
+            lambda[i] = math.Max(0.0, lambda[i] + rho*v)
+        }
+        for i, v := range hx {
+            mu[i] += rho*v
+        }
+        newviolation := violation(gx, hx)
+        if newviolation > 0.25*oldviolation {
+            rho = math.Min(10.0*rho, 1e12)
+        }
+        oldviolation = newviolation
+        ea = kktResidual(grad(x), gx, gjac(x), lambda, hx, hjac(x), mu)
+    }
+    return x, f(x), lambda, mu, ea, iter, nil
+}
+
+// SQP (Sequential quadratic programming)
+// Solves a quadratic model of the Lagrangian subject to the linearized constraints in each iteration, with a damped
+// BFGS approximation of the Hessian of the Lagrangian and a backtracking line search on the l1 merit function
+// input:
+// the function to find the minimum for (f), the gradient of f (grad, nil uses central differences), inequality constraints (g, nil for none), Jacobian of g (gjac, nil uses central differences), equality constraints (h, nil for none), Jacobian of h (hjac, nil uses central differences), initial guess (x0), KKT residual tolerance (es), maximum iterations (iter)
+// output:
+// the estimated x (x), function value (fx), multipliers of g (lambda), multipliers of h (mu), KKT residual (ea), iterations done (iter)
+func SQP(f func([]float64) float64, grad func([]float64) []float64, g func([]float64) []float64, gjac func([]float64) [][]float64, h func([]float64) []float64, hjac func([]float64) [][]float64, x0 []float64, es float64, maxit int) (x []float64, fx float64, lambda []float64, mu []float64, ea float64, iter int, err error) {
+    grad, _, err = checkMultivariate(f, grad, x0, nil, es)
+    if err != nil {
+        return nil, 0.0, nil, nil, 0.0, 0, err
+    }
+    g, gjac = constraintFunctions(g, gjac)
+    h, hjac = constraintFunctions(h, hjac)
+    n := len(x0)
+    x = append([]float64(nil), x0...)
+    fx = f(x)
+    gf := grad(x)
+    gx, hx := g(x), h(x)
+    Jg, Jh := gjac(x), hjac(x)
+    lambda = make([]float64, len(gx))
+    mu = make([]float64, len(hx))
+    B := make([][]float64, n)
+    for i := range B {
+        B[i] = make([]float64, n)
+        B[i][i] = 1.0
+    }
+    nu := 1.0
+    merit := func(fv float64, gv []float64, hv []float64) float64 {
+        s := fv
+        for _, v := range hv {
+            s += nu*math.Abs(v)
+        }
+        for _, v := range gv {
+            s += nu*math.Max(v, 0.0)
+        }
+        return s
+    }
+    ea = kktResidual(gf, gx, Jg, lambda, hx, Jh, mu)
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        if ea <= es {
+            break
+        }
+        // QP subproblem in the form C'p >= b: Jh p = -h and -Jg p >= g
+        C := make([][]float64, 0, len(hx) + len(gx))
+        b := make([]float64, 0, len(hx) + len(gx))
+        for i := range hx {
+            C = append(C, Jh[i])
+            b = append(b, -hx[i])
+        }
+        for i := range gx {
+            row := make([]float64, n)
+            for j := range row {
+                row[j] = -Jg[i][j]
+            }
+            C = append(C, row)
+            b = append(b, gx[i])
+        }
+        p, u, _, ray, qperr := dualActiveSet(B, gf, C, b, len(hx), nil)
+        if qperr != nil && ray != nil {
+            return x, fx, lambda, mu, ea, iter, errors.New("The linearized constraints are infeasible")
+        }
+        if qperr != nil {
+            return x, fx, lambda, mu, ea, iter, qperr
+        }
+        muhat := make([]float64, len(hx))
+        for i := range muhat {
+            muhat[i] = -u[i]
+        }
+        lambdahat := u[len(hx):]
+        // Line search on the l1 merit function
+        nu = math.Max(nu, 1.1*math.Max(norminf(muhat), norminf(lambdahat)))
+        phi0 := merit(fx, gx, hx)
+        infeas := 0.0
+        for _, v := range hx {
+            infeas += math.Abs(v)
+        }
+        for _, v := range gx {
+            infeas += math.Max(v, 0.0)
+        }
+        D := dot(gf, p) - nu*infeas
+        alpha := 1.0
+        var xnew, gnew, hnew []float64
+        var fnew float64
+        accepted := false
+        for k := 0; k < 40; k++ {
+            xnew = axpy(x, alpha, p)
+            fnew = f(xnew)
+            gnew, hnew = g(xnew), h(xnew)
+            if merit(fnew, gnew, hnew) <= phi0 + 1e-4*alpha*math.Min(D, 0.0) {
+                accepted = true
+                break
+            }
+            alpha *= 0.5
+        }
+        if !accepted {
+            return x, fx, lambda, mu, ea, iter, errors.New("No step decreasing the merit function found")
+        }
+        for i := range lambda {
+            lambda[i] += alpha*(lambdahat[i] - lambda[i])
+        }
+        for i := range mu {
+            mu[i] += alpha*(muhat[i] - mu[i])
+        }
+        gfnew := grad(xnew)
+        Jgnew, Jhnew := gjac(xnew), hjac(xnew)
+        // Damped BFGS update of the Hessian of the Lagrangian (Powell)
+        s := make([]float64, n)
+        for i := range s {
+            s[i] = xnew[i] - x[i]
+        }
+        gl0 := lagrangianGradient(gf, Jg, lambda, Jh, mu)
+        gl1 := lagrangianGradient(gfnew, Jgnew, lambda, Jhnew, mu)
+        y := make([]float64, n)
+        for i := range y {
+            y[i] = gl1[i] - gl0[i]
+        }
+        Bs := matvec(B, s)
+        sBs := dot(s, Bs)
+        sy := dot(s, y)
+        if sBs > 0.0 {
+            theta := 1.0
+            if sy < 0.2*sBs {
+                theta = 0.8*sBs/(sBs - sy)
+            }
+            for i := range y {
+                y[i] = theta*y[i] + (1.0 - theta)*Bs[i]
+            }
+            sy = dot(s, y)
+            for i := 0; i < n; i++ {
+                for j := 0; j < n; j++ {
+                    B[i][j] += y[i]*y[j]/sy - Bs[i]*Bs[j]/sBs
+                }
+            }
+        }
+        x, fx, gf, gx, hx, Jg, Jh = xnew, fnew, gfnew, gnew, hnew, Jgnew, Jhnew
+        ea = kktResidual(gf, gx, Jg, lambda, hx, Jh, mu)
+    }
+    return x, fx, lambda, mu, ea, iter, nil
+}
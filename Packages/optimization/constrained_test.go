@@ -0,0 +1,169 @@
+package optimization
+
+import (
+    "testing"
+    "errors"
+    "fmt"
+    "math"
+)
+
+// TestAugmentedLagrangianInequality calls optimization.AugmentedLagrangian with a linear function on a disk,
+// checking for the constrained minimum and its multiplier.
+func TestAugmentedLagrangianInequality(t *testing.T) {
+    f := func(x []float64) float64 {
+        return x[0] + x[1]
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{1.0, 1.0}
+    }
+    g := func(x []float64) []float64 {
+        return []float64{x[0]*x[0] + x[1]*x[1] - 2.0}
+    }
+    gjac := func(x []float64) [][]float64 {
+        return [][]float64{{2.0*x[0], 2.0*x[1]}}
+    }
+    es := 1e-6
+    maxit := 50
+    x, fx, lambda, mu, ea, iter, err := AugmentedLagrangian(f, grad, g, gjac, nil, nil, nil, []float64{0.5, 0.0}, es, maxit)
+    msg := fmt.Sprintf("%v, %f, %v, %v, %g, %d", x, fx, lambda, mu, ea, iter)
+    want := fmt.Sprintf("[-1 -1], -2, [0.5], [], %g, %d < %d", es, iter, maxit)
+    if err != nil || math.Abs(x[0] + 1.0) > 1e-5 || math.Abs(x[1] + 1.0) > 1e-5 || math.Abs(fx + 2.0) > 1e-5 || math.Abs(lambda[0] - 0.5) > 1e-5 || len(mu) != 0 || ea > es || iter >= maxit {
+        t.Fatalf(`AugmentedLagrangian(f, grad, g, gjac, nil, nil, nil, [0.5, 0], 1e-6, 50) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestAugmentedLagrangianEquality calls optimization.AugmentedLagrangian with a quadratic function on a line and
+// finite difference derivatives, checking for the constrained minimum and its multiplier.
+func TestAugmentedLagrangianEquality(t *testing.T) {
+    f := func(x []float64) float64 {
+        return x[0]*x[0] + 2.0*x[1]*x[1]
+    }
+    h := func(x []float64) []float64 {
+        return []float64{x[0] + x[1] - 3.0}
+    }
+    es := 1e-5
+    maxit := 50
+    x, fx, lambda, mu, ea, iter, err := AugmentedLagrangian(f, nil, nil, nil, h, nil, nil, []float64{0.0, 0.0}, es, maxit)
+    msg := fmt.Sprintf("%v, %f, %v, %v, %g, %d", x, fx, lambda, mu, ea, iter)
+    want := fmt.Sprintf("[2 1], 6, [], [-4], %g, %d < %d", es, iter, maxit)
+    if err != nil || math.Abs(x[0] - 2.0) > 1e-4 || math.Abs(x[1] - 1.0) > 1e-4 || math.Abs(fx - 6.0) > 1e-4 || len(lambda) != 0 || math.Abs(mu[0] + 4.0) > 1e-4 || ea > es || iter >= maxit {
+        t.Fatalf(`AugmentedLagrangian(f, nil, nil, nil, h, nil, nil, [0, 0], 1e-5, 50) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestAugmentedLagrangianEmpty calls optimization.AugmentedLagrangian with an empty initial guess,
+// checking for an error.
+func TestAugmentedLagrangianEmpty(t *testing.T) {
+    f := func(x []float64) float64 {
+        return x[0] + x[1]
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{1.0, 1.0}
+    }
+    g := func(x []float64) []float64 {
+        return []float64{x[0]*x[0] + x[1]*x[1] - 2.0}
+    }
+    gjac := func(x []float64) [][]float64 {
+        return [][]float64{{2.0*x[0], 2.0*x[1]}}
+    }
+    x, fx, lambda, mu, ea, iter, err := AugmentedLagrangian(f, grad, g, gjac, nil, nil, nil, nil, 1e-6, 10)
+    if x != nil || fx != 0.0 || lambda != nil || mu != nil || ea != 0.0 || iter != 0 || err == nil {
+        t.Fatalf(`AugmentedLagrangian(f, grad, g, gjac, nil, nil, nil, nil, 1e-6, 10) = %v, %f, %v, %v, %f, %d, %v, want nil, 0, nil, nil, 0, 0, error`, x, fx, lambda, mu, ea, iter, err)
+    }
+}
+
+// TestSQPInequality calls optimization.SQP with a linear function on a disk,
+// checking for the constrained minimum and its multiplier.
+func TestSQPInequality(t *testing.T) {
+    f := func(x []float64) float64 {
+        return x[0] + x[1]
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{1.0, 1.0}
+    }
+    g := func(x []float64) []float64 {
+        return []float64{x[0]*x[0] + x[1]*x[1] - 2.0}
+    }
+    gjac := func(x []float64) [][]float64 {
+        return [][]float64{{2.0*x[0], 2.0*x[1]}}
+    }
+    es := 1e-8
+    maxit := 100
+    x, fx, lambda, mu, ea, iter, err := SQP(f, grad, g, gjac, nil, nil, []float64{0.5, 0.0}, es, maxit)
+    msg := fmt.Sprintf("%v, %f, %v, %v, %g, %d", x, fx, lambda, mu, ea, iter)
+    want := fmt.Sprintf("[-1 -1], -2, [0.5], [], %g, %d < %d", es, iter, maxit)
+    if err != nil || math.Abs(x[0] + 1.0) > 1e-7 || math.Abs(x[1] + 1.0) > 1e-7 || math.Abs(fx + 2.0) > 1e-7 || math.Abs(lambda[0] - 0.5) > 1e-7 || len(mu) != 0 || ea > es || iter >= maxit {
+        t.Fatalf(`SQP(f, grad, g, gjac, nil, nil, [0.5, 0], 1e-8, 100) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestSQPMixed calls optimization.SQP with a quadratic function on a line and an additional inactive and an additional
+// active inequality, checking for the constrained minimum and the multipliers.
+func TestSQPMixed(t *testing.T) {
+    f := func(x []float64) float64 {
+        return x[0]*x[0] + 2.0*x[1]*x[1]
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{2.0*x[0], 4.0*x[1]}
+    }
+    h := func(x []float64) []float64 {
+        return []float64{x[0] + x[1] - 3.0}
+    }
+    // x1 <= 10 is inactive, x0 <= 1.5 moves the minimum to (1.5, 1.5) with lambda = (0, 3) and mu = -6
+    g := func(x []float64) []float64 {
+        return []float64{x[1] - 10.0, x[0] - 1.5}
+    }
+    es := 1e-8
+    maxit := 100
+    x, fx, lambda, mu, ea, iter, err := SQP(f, grad, g, nil, h, nil, []float64{0.0, 0.0}, es, maxit)
+    msg := fmt.Sprintf("%v, %f, %v, %v, %g, %d", x, fx, lambda, mu, ea, iter)
+    want := fmt.Sprintf("[1.5 1.5], 6.75, [0 3], [-6], %g, %d < %d", es, iter, maxit)
+    if err != nil || math.Abs(x[0] - 1.5) > 1e-6 || math.Abs(x[1] - 1.5) > 1e-6 || math.Abs(fx - 6.75) > 1e-6 || math.Abs(lambda[0]) > 1e-6 || math.Abs(lambda[1] - 3.0) > 1e-5 || math.Abs(mu[0] + 6.0) > 1e-5 || ea > es || iter >= maxit {
+        t.Fatalf(`SQP(f, grad, g, nil, h, nil, [0, 0], 1e-8, 100) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestSQPInfeasible calls optimization.SQP with the contradicting constraints x0 <= -1 and x0 = 1,
+// checking for an error.
+func TestSQPInfeasible(t *testing.T) {
+    f := func(x []float64) float64 {
+        return x[0]*x[0] + 2.0*x[1]*x[1]
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{2.0*x[0], 4.0*x[1]}
+    }
+    g := func(x []float64) []float64 {
+        return []float64{x[0] + 1.0}
+    }
+    h := func(x []float64) []float64 {
+        return []float64{x[0] - 1.0}
+    }
+    _, _, _, _, _, _, err := SQP(f, grad, g, nil, h, nil, []float64{0.0, 0.0}, 1e-8, 100)
+    if err == nil {
+        t.Fatalf(`SQP(f, grad, g, nil, h, nil, [0, 0], 1e-8, 100) = %v, want error`, err)
+    }
+}
+
+// TestAugmentedLagrangianInnerFailure calls optimization.AugmentedLagrangian with an inner minimizer that fails,
+// checking that the initial guess is returned with the error of the inner minimizer.
+func TestAugmentedLagrangianInnerFailure(t *testing.T) {
+    f := func(x []float64) float64 {
+        return x[0] + x[1]
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{1.0, 1.0}
+    }
+    g := func(x []float64) []float64 {
+        return []float64{x[0]*x[0] + x[1]*x[1] - 2.0}
+    }
+    gjac := func(x []float64) [][]float64 {
+        return [][]float64{{2.0*x[0], 2.0*x[1]}}
+    }
+    fail := func(f func([]float64) float64, grad func([]float64) []float64, x0 []float64, es float64, maxit int) ([]float64, float64, float64, int, error) {
+        return nil, 0.0, 0.0, 0, errors.New("no step")
+    }
+    x, fx, _, _, _, iter, err := AugmentedLagrangian(f, grad, g, gjac, nil, nil, fail, []float64{0.5, 0.0}, 1e-6, 50)
+    if err == nil || err.Error() != "no step" || x[0] != 0.5 || x[1] != 0.0 || fx != 0.5 || iter != 0 {
+        t.Fatalf(`AugmentedLagrangian(f, grad, g, gjac, nil, nil, fail, [0.5, 0], 1e-6, 50) = %v, %f, %d, %v, want [0.5 0], 0.5, 0, no step`, x, fx, iter, err)
+    }
+}
@@ -0,0 +1,289 @@
+package optimization
+
+import (
+    "errors"
+    "math"
+    "math/rand"
+    "sort"
+    "sync"
+)
+
+// DEStrategy selects the mutation and crossover of differential evolution
+type DEStrategy int
+
+const (
+    // DERand1Bin mutates v = x_r1 + F(x_r2 - x_r3), robust and exploring
+    DERand1Bin DEStrategy = iota
+    // DEBest1Bin mutates v = x_best + F(x_r1 - x_r2), fast but greedy
+    DEBest1Bin
+    // DECurrentToBest1Bin mutates v = x_i + F(x_best - x_i) + F(x_r1 - x_r2), with SHADE x_best is drawn from the best
+    // tenth of the population and x_r2 from the population and the archive of replaced parents (current-to-pbest/1)
+    DECurrentToBest1Bin
+)
+
+// DEAdaptation selects how the scale factor F and the crossover rate CR are chosen
+type DEAdaptation int
+
+const (
+    // DEFixed uses the given F and CR for every trial vector
+    DEFixed DEAdaptation = iota
+    // DEjDE keeps F and CR per individual and resamples them with probability 0.1 (Brest et al.), successful values survive
+    DEjDE
+    // DESHADE samples F and CR around a memory of the successful values of the last generations (Tanabe and Fukunaga)
+    DESHADE
+)
+
+// evaluate computes f for every point using up to workers goroutines
+func evaluate(f func([]float64) float64, points [][]float64, workers int) []float64 {
+    fx := make([]float64, len(points))
+    if workers <= 1 {
+        for i, p := range points {
+            fx[i] = f(p)
+        }
+        return fx
+    }
+    next := make(chan int)
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range next {
+                fx[i] = f(points[i])
+            }
+        }()
+    }
+    for i := range points {
+        next <- i
+    }
+    close(next)
+    wg.Wait()
+    return fx
+}
+
+// checkBox validates the bounds of the population based global minimizers
+func checkBox(lower []float64, upper []float64) error {
+    if len(lower) == 0 || len(lower) != len(upper) {
+        return errors.New("lower and upper must be nonempty and of the same length")
+    }
+    for i := range lower {
+        if !(lower[i] < upper[i]) || math.IsInf(lower[i], 0) || math.IsInf(upper[i], 0) {
+            return errors.New("lower must be less than upper and both must be finite")
+        }
+    }
+    return nil
+}
+
+// DifferentialEvolution (Differential evolution for bounded multivariate functions)
+// Evolves a population of np points in the box [lower, upper], every generation each point competes with a trial vector
+// built by mutation and binomial crossover and is replaced when the trial is not worse. Trial components outside the
+// box are set halfway between the parent and the violated bound. The trial vectors are generated from the seeded
+// random number generator before they are evaluated by up to workers goroutines, so the result does not depend on workers.
+// input:
+// the function to find the minimum for (f), lower bounds (lower), upper bounds (upper), population size of at least 4 (np), mutation strategy (strategy), parameter adaptation (adaptation), scale factor or its initial value (F), crossover rate or its initial value (CR), seed of the random number generator (seed), number of goroutines evaluating f (workers), relative spread of the population function values (es), maximum generations (iter)
+// output:
+// the best x (x), function value (fx), relative spread of the population function values (ea), generations done (iter)
+func DifferentialEvolution(f func([]float64) float64, lower []float64, upper []float64, np int, strategy DEStrategy, adaptation DEAdaptation, F float64, CR float64, seed int64, workers int, es float64, maxit int) (x []float64, fx float64, ea float64, iter int, err error) {
+    if err = checkBox(lower, upper); err != nil {
+        return nil, 0.0, 0.0, 0, err
+    }
+    if np < 4 {
+        return nil, 0.0, 0.0, 0, errors.New("np must be at least 4")
+    }
+    if strategy < DERand1Bin || strategy > DECurrentToBest1Bin {
+        return nil, 0.0, 0.0, 0, errors.New("Unknown differential evolution strategy")
+    }
+    if adaptation < DEFixed || adaptation > DESHADE {
+        return nil, 0.0, 0.0, 0, errors.New("Unknown differential evolution adaptation")
+    }
+    if F <= 0.0 || F > 2.0 || CR < 0.0 || CR > 1.0 {
+        return nil, 0.0, 0.0, 0, errors.New("F must be in (0, 2] and CR in [0, 1]")
+    }
+    if es < 0.0 {
+        return nil, 0.0, 0.0, 0, errors.New("es must be greater than 0")
+    }
+    n := len(lower)
+    rng := rand.New(rand.NewSource(seed))
+    pop := make([][]float64, np)
+    for i := range pop {
+        pop[i] = make([]float64, n)
+        for j := range pop[i] {
+            pop[i][j] = lower[j] + rng.Float64()*(upper[j] - lower[j])
+        }
+    }
+    fpop := evaluate(f, pop, workers)
+    // Per individual parameters for jDE and the success memory for SHADE
+    Fs := make([]float64, np)
+    CRs := make([]float64, np)
+    for i := range Fs {
+        Fs[i], CRs[i] = F, CR
+    }
+    const H = 6
+    MF := make([]float64, H)
+    MCR := make([]float64, H)
+    for k := range MF {
+        MF[k], MCR[k] = F, CR
+    }
+    memory := 0
+    var archive [][]float64
+    pbest := int(math.Max(2.0, math.Round(0.1*float64(np))))
+    // distinct draws k indices different from i and from each other
+    distinct := func(i int, k int, limit int) []int {
+        idx := make([]int, 0, k)
+        for len(idx) < k {
+            r := rng.Intn(limit)
+            ok := r != i
+            for _, v := range idx {
+                ok = ok && r != v
+            }
+            if ok {
+                idx = append(idx, r)
+            }
+        }
+        return idx
+    }
+    spread := func() (int, float64) {
+        best := 0
+        worst := 0
+        for i := range fpop {
+            if fpop[i] < fpop[best] {
+                best = i
+            }
+            if fpop[i] > fpop[worst] {
+                worst = i
+            }
+        }
+        return best, (fpop[worst] - fpop[best])/math.Max(math.Abs(fpop[best]), 1.0)
+    }
+    best, ea := spread()
+    trials := make([][]float64, np)
+    tF := make([]float64, np)
+    tCR := make([]float64, np)
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        if ea <= es {
+            break
+        }
+        var order []int
+        if adaptation == DESHADE && strategy == DECurrentToBest1Bin {
+            order = make([]int, np)
+            for i := range order {
+                order[i] = i
+            }
+            sort.SliceStable(order, func(a, b int) bool { return fpop[order[a]] < fpop[order[b]] })
+        }
+        for i := 0; i < np; i++ {
+            // Scale factor and crossover rate of this trial
+            switch adaptation {
+            case DEjDE:
+                tF[i], tCR[i] = Fs[i], CRs[i]
+                if rng.Float64() < 0.1 {
+                    tF[i] = 0.1 + 0.9*rng.Float64()
+                }
+                if rng.Float64() < 0.1 {
+                    tCR[i] = rng.Float64()
+                }
+            case DESHADE:
+                k := rng.Intn(H)
+                tCR[i] = math.Min(math.Max(MCR[k] + 0.1*rng.NormFloat64(), 0.0), 1.0)
+                tF[i] = 0.0
+                for tF[i] <= 0.0 {
+                    tF[i] = MF[k] + 0.1*math.Tan(math.Pi*(rng.Float64() - 0.5))
+                }
+                tF[i] = math.Min(tF[i], 1.0)
+            default:
+                tF[i], tCR[i] = F, CR
+            }
+            // Mutation
+            v := make([]float64, n)
+            switch strategy {
+            case DEBest1Bin:
+                r := distinct(i, 2, np)
+                for j := range v {
+                    v[j] = pop[best][j] + tF[i]*(pop[r[0]][j] - pop[r[1]][j])
+                }
+            case DECurrentToBest1Bin:
+                b := pop[best]
+                x2 := func(k int) []float64 { return pop[k] }
+                r := distinct(i, 2, np)
+                if order != nil {
+                    b = pop[order[rng.Intn(pbest)]]
+                    r = distinct(i, 1, np)
+                    for len(r) < 2 { // x_r2 from the population or the archive
+                        k := rng.Intn(np + len(archive))
+                        if k != i && k != r[0] {
+                            r = append(r, k)
+                        }
+                    }
+                    x2 = func(k int) []float64 {
+                        if k < np {
+                            return pop[k]
+                        }
+                        return archive[k-np]
+                    }
+                }
+                for j := range v {
+                    v[j] = pop[i][j] + tF[i]*(b[j] - pop[i][j]) + tF[i]*(pop[r[0]][j] - x2(r[1])[j])
+                }
+            default:
+                r := distinct(i, 3, np)
+                for j := range v {
+                    v[j] = pop[r[0]][j] + tF[i]*(pop[r[1]][j] - pop[r[2]][j])
+                }
+            }
+            // Binomial crossover, one component always comes from the mutant
+            jrand := rng.Intn(n)
+            trial := make([]float64, n)
+            for j := range trial {
+                if j == jrand || rng.Float64() < tCR[i] {
+                    trial[j] = v[j]
+                } else {
+                    trial[j] = pop[i][j]
+                }
+                if trial[j] < lower[j] {
+                    trial[j] = 0.5*(lower[j] + pop[i][j])
+                }
+                if trial[j] > upper[j] {
+                    trial[j] = 0.5*(upper[j] + pop[i][j])
+                }
+            }
+            trials[i] = trial
+        }
+        ftrials := evaluate(f, trials, workers)
+        // Selection
+        var SF, SCR, weights []float64
+        for i := 0; i < np; i++ {
+            if ftrials[i] <= fpop[i] {
+                if ftrials[i] < fpop[i] && adaptation == DESHADE {
+                    SF = append(SF, tF[i])
+                    SCR = append(SCR, tCR[i])
+                    weights = append(weights, fpop[i] - ftrials[i])
+                    archive = append(archive, pop[i])
+                }
+                pop[i], fpop[i] = trials[i], ftrials[i]
+                Fs[i], CRs[i] = tF[i], tCR[i]
+            }
+        }
+        for len(archive) > np {
+            k := rng.Intn(len(archive))
+            archive[k] = archive[len(archive)-1]
+            archive = archive[:len(archive)-1]
+        }
+        if len(SF) > 0 { // weighted Lehmer mean of F and weighted mean of CR
+            sw, sf, sf2, scr := 0.0, 0.0, 0.0, 0.0
+            for k := range SF {
+                sw += weights[k]
+            }
+            for k := range SF {
+                w := weights[k]/sw
+                sf += w*SF[k]
+                sf2 += w*SF[k]*SF[k]
+                scr += w*SCR[k]
+            }
+            MF[memory], MCR[memory] = sf2/sf, scr
+            memory = (memory + 1)%H
+        }
+        best, ea = spread()
+    }
+    return pop[best], fpop[best], ea, iter, nil
+}
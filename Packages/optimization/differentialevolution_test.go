@@ -0,0 +1,105 @@
+package optimization
+
+import (
+    "testing"
+    "fmt"
+    "math"
+)
+
+// TestDifferentialEvolution calls optimization.DifferentialEvolution with the five dimensional Rastrigin function and
+// the rand/1/bin strategy, checking for the global minimum.
+func TestDifferentialEvolution(t *testing.T) {
+    rastrigin := func(x []float64) float64 { // global minimum 0 at the origin surrounded by a grid of local minima
+        s := 10.0*float64(len(x))
+        for _, v := range x {
+            s += v*v - 10.0*math.Cos(2.0*math.Pi*v)
+        }
+        return s
+    }
+    lower := []float64{-5.12, -5.12, -5.12, -5.12, -5.12}
+    upper := []float64{5.12, 5.12, 5.12, 5.12, 5.12}
+    es := 1e-10
+    maxit := 2000
+    x, fx, ea, iter, err := DifferentialEvolution(rastrigin, lower, upper, 50, DERand1Bin, DEFixed, 0.5, 0.9, 1, 1, es, maxit)
+    msg := fmt.Sprintf("%v, %g, %g, %d", x, fx, ea, iter)
+    want := fmt.Sprintf("[0 0 0 0 0], 0, %g, %d < %d", es, iter, maxit)
+    if err != nil || norminf(x) > 1e-5 || fx > 1e-9 || ea > es || iter >= maxit {
+        t.Fatalf(`DifferentialEvolution(rastrigin, lower, upper, 50, DERand1Bin, DEFixed, 0.5, 0.9, 1, 1, 1e-10, 2000) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestDifferentialEvolutionAdaptive calls optimization.DifferentialEvolution with the four dimensional Rosenbrock
+// function for jDE with best/1/bin and SHADE with current-to-pbest/1, checking for the minimum at (1, 1, 1, 1).
+func TestDifferentialEvolutionAdaptive(t *testing.T) {
+    f := func(x []float64) float64 { // extended Rosenbrock function, the minimum is at x = 1
+        s := 0.0
+        for i := 0; i + 1 < len(x); i += 2 {
+            s += (1.0 - x[i])*(1.0 - x[i]) + 100.0*(x[i+1] - x[i]*x[i])*(x[i+1] - x[i]*x[i])
+        }
+        return s
+    }
+    lower := []float64{-5.0, -5.0, -5.0, -5.0}
+    upper := []float64{5.0, 5.0, 5.0, 5.0}
+    es := 1e-12
+    maxit := 3000
+    cases := []struct {
+        strategy   DEStrategy
+        adaptation DEAdaptation
+    }{{DEBest1Bin, DEjDE}, {DECurrentToBest1Bin, DESHADE}}
+    for _, c := range cases {
+        x, fx, ea, iter, err := DifferentialEvolution(f, lower, upper, 40, c.strategy, c.adaptation, 0.5, 0.9, 1, 1, es, maxit)
+        msg := fmt.Sprintf("%v, %g, %g, %d", x, fx, ea, iter)
+        want := fmt.Sprintf("[1 1 1 1], 0, %g, %d < %d", es, iter, maxit)
+        if err != nil || math.Abs(x[0] - 1.0) > 1e-5 || math.Abs(x[3] - 1.0) > 1e-5 || fx > 1e-10 || ea > es || iter >= maxit {
+            t.Fatalf(`DifferentialEvolution(rosenbrock, lower, upper, 40, %d, %d, 0.5, 0.9, 1, 1, 1e-12, 3000) = %q, %v, want match for %#v, nil`, c.strategy, c.adaptation, msg, err, want)
+        }
+    }
+}
+
+// TestDifferentialEvolutionWorkers calls optimization.DifferentialEvolution with one and with four workers,
+// checking that the results are identical.
+func TestDifferentialEvolutionWorkers(t *testing.T) {
+    rastrigin := func(x []float64) float64 { // global minimum 0 at the origin surrounded by a grid of local minima
+        s := 10.0*float64(len(x))
+        for _, v := range x {
+            s += v*v - 10.0*math.Cos(2.0*math.Pi*v)
+        }
+        return s
+    }
+    lower := []float64{-5.12, -5.12, -5.12}
+    upper := []float64{5.12, 5.12, 5.12}
+    x1, fx1, ea1, iter1, err1 := DifferentialEvolution(rastrigin, lower, upper, 30, DECurrentToBest1Bin, DESHADE, 0.5, 0.5, 42, 1, 1e-8, 200)
+    x4, fx4, ea4, iter4, err4 := DifferentialEvolution(rastrigin, lower, upper, 30, DECurrentToBest1Bin, DESHADE, 0.5, 0.5, 42, 4, 1e-8, 200)
+    if err1 != nil || err4 != nil || fmt.Sprint(x1) != fmt.Sprint(x4) || fx1 != fx4 || ea1 != ea4 || iter1 != iter4 {
+        t.Fatalf(`DifferentialEvolution with 4 workers = %v, %g, %g, %d, %v, want %v, %g, %g, %d, %v`, x4, fx4, ea4, iter4, err4, x1, fx1, ea1, iter1, err1)
+    }
+}
+
+// TestDifferentialEvolutionErrors calls optimization.DifferentialEvolution with invalid bounds, population size,
+// parameters, strategy and adaptation, checking for errors.
+func TestDifferentialEvolutionErrors(t *testing.T) {
+    rastrigin := func(x []float64) float64 { // global minimum 0 at the origin surrounded by a grid of local minima
+        s := 10.0*float64(len(x))
+        for _, v := range x {
+            s += v*v - 10.0*math.Cos(2.0*math.Pi*v)
+        }
+        return s
+    }
+    lower := []float64{-1.0, -1.0}
+    upper := []float64{1.0, 1.0}
+    calls := []struct {
+        lower      []float64
+        upper      []float64
+        np         int
+        strategy   DEStrategy
+        adaptation DEAdaptation
+        F          float64
+    }{{nil, nil, 10, DERand1Bin, DEFixed, 0.5}, {upper, lower, 10, DERand1Bin, DEFixed, 0.5}, {lower, upper, 3, DERand1Bin, DEFixed, 0.5},
+        {lower, upper, 10, DERand1Bin, DEFixed, 0.0}, {lower, upper, 10, DEStrategy(3), DEFixed, 0.5}, {lower, upper, 10, DERand1Bin, DEAdaptation(-1), 0.5}}
+    for _, c := range calls {
+        x, fx, ea, iter, err := DifferentialEvolution(rastrigin, c.lower, c.upper, c.np, c.strategy, c.adaptation, c.F, 0.9, 1, 1, 1e-8, 10)
+        if x != nil || fx != 0.0 || ea != 0.0 || iter != 0 || err == nil {
+            t.Fatalf(`DifferentialEvolution(rastrigin, %v, %v, %d, %d, %d, %g, 0.9, 1, 1, 1e-8, 10) = %v, %g, %g, %d, %v, want nil, 0, 0, 0, error`, c.lower, c.upper, c.np, c.strategy, c.adaptation, c.F, x, fx, ea, iter, err)
+        }
+    }
+}
@@ -0,0 +1,219 @@
+package optimization
+
+import (
+    "errors"
+    "math"
+    "sort"
+)
+
+// Piyavskii (Piyavskii-Shubert global minimization)
+// Builds the saw-tooth lower bound f(xi) - L|x - xi| from the sampled points and samples where it is lowest, so the
+// error estimate bounds the distance of fx from the global minimum on [xl, xu] when L is a Lipschitz constant of f.
+// With L <= 0 the constant is estimated as twice the largest observed slope, the bound then is only an estimate.
+// input:
+// the function to find the minimum for (f), lower limit (xl), upper limit (xu), Lipschitz constant (L), error deviation (es), maximum iterations (maxit)
+// output:
+// the estimated x (x), function value (fx), gap between fx and the lower bound (ea), iterations done (iter)
+func Piyavskii(f func(float64) float64, xl float64, xu float64, L float64, es float64, maxit int) (x float64, fx float64, ea float64, iter int, err error) {
+    if es < 0.0 {
+        return 0.0, 0.0, 0.0, 0, errors.New("es must be greater than 0")
+    }
+    if maxit < 1 {
+        return 0.0, 0.0, 0.0, 0, errors.New("maxit must be at least 1")
+    }
+    if xl >= xu {
+        return 0.0, 0.0, 0.0, 0, errors.New("xl must be less than xu")
+    }
+    xs := []float64{xl, xu}
+    fs := []float64{f(xl), f(xu)}
+    x, fx = xl, fs[0]
+    if fs[1] < fx {
+        x, fx = xu, fs[1]
+    }
+    iter = 0
+    for ; ; iter ++ {
+        K := L
+        if K <= 0.0 {
+            K = 1e-8
+            for i := 0; i + 1 < len(xs); i++ {
+                K = math.Max(K, 2.0*math.Abs(fs[i+1] - fs[i])/(xs[i+1] - xs[i]))
+            }
+        }
+        // The interval with the lowest bound and the point where the bound attains it
+        k := 0
+        lb := math.Inf(1)
+        for i := 0; i + 1 < len(xs); i++ {
+            b := (fs[i] + fs[i+1])/2.0 - K*(xs[i+1] - xs[i])/2.0
+            if b < lb {
+                k, lb = i, b
+            }
+        }
+        ea = fx - lb
+        if ea <= es || iter >= maxit {
+            break
+        }
+        xn := (xs[k] + xs[k+1])/2.0 + (fs[k] - fs[k+1])/(2.0*K)
+        xn = math.Min(math.Max(xn, xs[k]), xs[k+1])
+        fn := f(xn)
+        if fn < fx {
+            x, fx = xn, fn
+        }
+        xs = append(xs, 0.0)
+        fs = append(fs, 0.0)
+        copy(xs[k+2:], xs[k+1:])
+        copy(fs[k+2:], fs[k+1:])
+        xs[k+1], fs[k+1] = xn, fn
+    }
+    return x, fx, ea, iter, nil
+}
+
+// rectangle is a hyperrectangle of DIRECT in the unit cube, side i has length 3^-level[i]
+type rectangle struct {
+    c     []float64
+    f     float64
+    level []int
+    d     float64
+}
+
+// newRectangle returns the rectangle with center c and the given levels, d is half its diagonal
+func newRectangle(c []float64, f float64, level []int) rectangle {
+    sorted := append([]int(nil), level...)
+    sort.Ints(sorted) // the same sizes must compare equal whatever the order of the sides
+    d := 0.0
+    for _, l := range sorted {
+        d += math.Pow(9.0, -float64(l))
+    }
+    return rectangle{c, f, level, 0.5*math.Sqrt(d)}
+}
+
+// DIRECT (DIviding RECTangles global minimization)
+// Jones' Lipschitzian method without a Lipschitz constant: each iteration trisects the potentially optimal
+// rectangles, the ones with the lowest lower bound for some constant, along their longest sides. Every rectangle is
+// divided eventually so the samples become dense in the box, the error estimate is the largest half diagonal of a
+// rectangle in the box scaled to the unit cube, meaning every point lies at most ea from a sampled center.
+// input:
+// the function to find the minimum for (f), lower bounds (lower), upper bounds (upper), error deviation (es), maximum iterations (maxit)
+// output:
+// the estimated x (x), function value (fx), largest unexplored half diagonal (ea), iterations done (iter)
+func DIRECT(f func([]float64) float64, lower []float64, upper []float64, es float64, maxit int) (x []float64, fx float64, ea float64, iter int, err error) {
+    if err = checkBox(lower, upper); err != nil {
+        return nil, 0.0, 0.0, 0, err
+    }
+    if es < 0.0 {
+        return nil, 0.0, 0.0, 0, errors.New("es must be greater than 0")
+    }
+    if maxit < 1 {
+        return nil, 0.0, 0.0, 0, errors.New("maxit must be at least 1")
+    }
+    const eps = 1e-4
+    n := len(lower)
+    scale := func(c []float64) []float64 {
+        y := make([]float64, n)
+        for i := range y {
+            y[i] = lower[i] + c[i]*(upper[i] - lower[i])
+        }
+        return y
+    }
+    c := make([]float64, n)
+    for i := range c {
+        c[i] = 0.5
+    }
+    rects := []rectangle{newRectangle(c, f(scale(c)), make([]int, n))}
+    best := 0
+    iter = 0
+    for ; ; iter ++ {
+        ea = 0.0
+        for _, r := range rects {
+            ea = math.Max(ea, r.d)
+        }
+        if ea <= es || iter >= maxit {
+            break
+        }
+        // Lowest rectangle of every size no smaller than the best one, sorted by size
+        fmin := rects[best].f
+        lowest := map[float64]int{}
+        for i, r := range rects {
+            if r.d < rects[best].d {
+                continue
+            }
+            if j, ok := lowest[r.d]; !ok || r.f < rects[j].f {
+                lowest[r.d] = i
+            }
+        }
+        candidates := make([]int, 0, len(lowest))
+        for _, i := range lowest {
+            candidates = append(candidates, i)
+        }
+        sort.Slice(candidates, func(a, b int) bool { return rects[candidates[a]].d < rects[candidates[b]].d })
+        // Lower right convex hull of (d, f), starting at the best rectangle
+        var hull []int
+        for _, i := range candidates {
+            for len(hull) >= 2 {
+                o, a := rects[hull[len(hull)-2]], rects[hull[len(hull)-1]]
+                if (a.d - o.d)*(rects[i].f - o.f) - (a.f - o.f)*(rects[i].d - o.d) > 0.0 {
+                    break
+                }
+                hull = hull[:len(hull)-1]
+            }
+            hull = append(hull, i)
+        }
+        var selected []int
+        for k, i := range hull {
+            if k + 1 < len(hull) {
+                j := hull[k+1]
+                K := (rects[j].f - rects[i].f)/(rects[j].d - rects[i].d)
+                if rects[i].f - K*rects[i].d > fmin - eps*math.Abs(fmin) {
+                    continue
+                }
+            }
+            selected = append(selected, i)
+        }
+        for _, i := range selected {
+            r := rects[i]
+            m := r.level[0]
+            for _, l := range r.level {
+                if l < m {
+                    m = l
+                }
+            }
+            delta := math.Pow(3.0, -float64(m + 1))
+            var dims []int
+            var w []float64
+            var sides [][2]rectangle
+            for d, l := range r.level {
+                if l != m {
+                    continue
+                }
+                var pair [2]rectangle
+                for s, sign := range []float64{-1.0, 1.0} {
+                    cn := append([]float64(nil), r.c...)
+                    cn[d] += sign*delta
+                    pair[s] = rectangle{c: cn, f: f(scale(cn))}
+                }
+                dims = append(dims, d)
+                w = append(w, math.Min(pair[0].f, pair[1].f))
+                sides = append(sides, pair)
+            }
+            order := make([]int, len(dims))
+            for k := range order {
+                order[k] = k
+            }
+            sort.SliceStable(order, func(a, b int) bool { return w[order[a]] < w[order[b]] })
+            // The dimension with the lowest sample is split first, leaving the largest rectangles around the best samples
+            level := append([]int(nil), r.level...)
+            for _, k := range order {
+                level[dims[k]]++
+                for _, s := range sides[k] {
+                    rects = append(rects, newRectangle(s.c, s.f, append([]int(nil), level...)))
+                }
+            }
+            rects[i] = newRectangle(r.c, r.f, level)
+        }
+        for i, r := range rects {
+            if r.f < rects[best].f {
+                best = i
+            }
+        }
+    }
+    return scale(rects[best].c), rects[best].f, ea, iter, nil
+}
@@ -0,0 +1,114 @@
+package optimization
+
+import (
+    "testing"
+    "fmt"
+    "math"
+)
+
+// TestPiyavskii calls optimization.Piyavskii with a multimodal function on [2.7, 7.5], a Lipschitz constant, error
+// limit and max iterations, checking for the global minimum.
+func TestPiyavskii(t *testing.T) {
+    f := func(x float64) float64 { return math.Sin(x) + math.Sin(10.0*x/3.0) }
+    xwant, fxwant := 5.145735, -1.899599
+    es := 1e-4
+    maxit := 1000
+    x, fx, ea, iter, err := Piyavskii(f, 2.7, 7.5, 1.0 + 10.0/3.0, es, maxit)
+    msg := fmt.Sprintf("%f, %f, %g, %d", x, fx, ea, iter)
+    want := fmt.Sprintf("%f, %f, %g, %d < %d", xwant, fxwant, es, iter, maxit)
+    if err != nil || math.Abs(x - xwant) > 1e-3 || math.Abs(fx - fxwant) > 1e-5 || ea > es || iter >= maxit {
+        t.Fatalf(`Piyavskii(f, 2.7, 7.5, 4.33, 1e-4, 1000) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestPiyavskiiEstimated calls optimization.Piyavskii without a Lipschitz constant, checking that the estimated
+// constant still finds the global minimum that Goldmin misses.
+func TestPiyavskiiEstimated(t *testing.T) {
+    f := func(x float64) float64 { return x*x/10.0 - 2.0*math.Sin(3.0*x) }
+    x, fx, ea, iter, err := Piyavskii(f, -4.0, 10.0, 0.0, 1e-4, 2000)
+    _, fref, _, _, _ := Brentmin(f, 0.4, 0.6, 1e-10, 200)
+    if err != nil || math.Abs(fx - fref) > 1e-4 || ea > 1e-4 {
+        t.Fatalf(`Piyavskii(f, -4, 10, 0, 1e-4, 2000) = %f, %f, %g, %d, %v, want %f`, x, fx, ea, iter, err, fref)
+    }
+    _, fx, _, _, _ = Goldmin(f, -4.0, 10.0, 1e-6, 1000)
+    if math.Abs(fx - fref) < 1e-4 {
+        t.Fatalf(`Goldmin(f, -4, 10, 1e-6, 1000) = %f, want a local minimum`, fx)
+    }
+}
+
+// TestDIRECT calls optimization.DIRECT with the Branin function on [-5, 10] x [0, 15], checking for one of its
+// global minima.
+func TestDIRECT(t *testing.T) {
+    branin := func(x []float64) float64 { // three global minima with value 0.397887
+        a := x[1] - 5.1/(4.0*math.Pi*math.Pi)*x[0]*x[0] + 5.0/math.Pi*x[0] - 6.0
+        return a*a + 10.0*(1.0 - 1.0/(8.0*math.Pi))*math.Cos(x[0]) + 10.0
+    }
+    fxwant := 0.397887
+    minima := [][]float64{{-math.Pi, 12.275}, {math.Pi, 2.275}, {9.42478, 2.475}}
+    x, fx, ea, iter, err := DIRECT(branin, []float64{-5.0, 0.0}, []float64{10.0, 15.0}, 0.0, 100)
+    if err != nil || math.Abs(fx - fxwant) > 1e-4 || iter != 100 {
+        t.Fatalf(`DIRECT(branin, [-5, 0], [10, 15], 0, 100) = %v, %f, %g, %d, %v, want %f`, x, fx, ea, iter, err, fxwant)
+    }
+    found := false
+    for _, m := range minima {
+        found = found || math.Abs(x[0] - m[0]) < 1e-2 && math.Abs(x[1] - m[1]) < 1e-2
+    }
+    if !found {
+        t.Fatalf(`DIRECT(branin, [-5, 0], [10, 15], 0, 100) = %v, want one of %v`, x, minima)
+    }
+}
+
+// TestDIRECTCoverage calls optimization.DIRECT with the Rastrigin function and a coverage limit, checking that the
+// error estimate is reached and the global minimum is found.
+func TestDIRECTCoverage(t *testing.T) {
+    rastrigin := func(x []float64) float64 { // global minimum 0 at the origin surrounded by a grid of local minima
+        s := 10.0*float64(len(x))
+        for _, v := range x {
+            s += v*v - 10.0*math.Cos(2.0*math.Pi*v)
+        }
+        return s
+    }
+    es := 0.02
+    x, fx, ea, iter, err := DIRECT(rastrigin, []float64{-5.12, -5.12}, []float64{4.0, 4.0}, es, 1000)
+    if err != nil || ea > es || fx > 1e-3 || iter >= 1000 {
+        t.Fatalf(`DIRECT(rastrigin, [-5.12, -5.12], [4, 4], 0.02, 1000) = %v, %g, %g, %d, %v, want 0`, x, fx, ea, iter, err)
+    }
+}
+
+// TestPiyavskiiInput calls optimization.Piyavskii with a negative es, an empty interval and maxit = 0,
+// checking for errors.
+func TestPiyavskiiInput(t *testing.T) {
+    f := func(x float64) float64 { return x*x }
+    calls := []struct {
+        xl    float64
+        xu    float64
+        es    float64
+        maxit int
+    }{{-1.0, 1.0, -1.0, 100}, {1.0, -1.0, 1e-4, 100}, {-1.0, 1.0, 1e-4, 0}}
+    for _, c := range calls {
+        x, fx, ea, iter, err := Piyavskii(f, c.xl, c.xu, 2.0, c.es, c.maxit)
+        if x != 0.0 || fx != 0.0 || ea != 0.0 || iter != 0 || err == nil {
+            t.Fatalf(`Piyavskii(f, %g, %g, 2, %g, %d) = %f, %f, %f, %d, %v, want 0, 0, 0, 0, error`, c.xl, c.xu, c.es, c.maxit, x, fx, ea, iter, err)
+        }
+    }
+}
+
+// TestDIRECTInput calls optimization.DIRECT with an invalid box, a negative es and maxit = 0, checking for errors.
+func TestDIRECTInput(t *testing.T) {
+    branin := func(x []float64) float64 { // three global minima with value 0.397887
+        a := x[1] - 5.1/(4.0*math.Pi*math.Pi)*x[0]*x[0] + 5.0/math.Pi*x[0] - 6.0
+        return a*a + 10.0*(1.0 - 1.0/(8.0*math.Pi))*math.Cos(x[0]) + 10.0
+    }
+    calls := []struct {
+        lower []float64
+        upper []float64
+        es    float64
+        maxit int
+    }{{[]float64{1.0, 0.0}, []float64{0.0, 15.0}, 0.0, 100}, {[]float64{-5.0, 0.0}, []float64{10.0, 15.0}, -1.0, 100}, {[]float64{-5.0, 0.0}, []float64{10.0, 15.0}, 0.0, 0}}
+    for _, c := range calls {
+        x, fx, ea, iter, err := DIRECT(branin, c.lower, c.upper, c.es, c.maxit)
+        if x != nil || fx != 0.0 || ea != 0.0 || iter != 0 || err == nil {
+            t.Fatalf(`DIRECT(branin, %v, %v, %g, %d) = %v, %f, %f, %d, %v, want nil, 0, 0, 0, error`, c.lower, c.upper, c.es, c.maxit, x, fx, ea, iter, err)
+        }
+    }
+}
@@ -1,3 +1,7 @@
 module example.com/optimization
 
 go 1.15
+
+replace example.com/numdiff => ../numdiff
+
+require example.com/numdiff v0.0.0-00010101000000-000000000000
@@ -0,0 +1,96 @@
+package optimization
+
+import (
+    "errors"
+    "example.com/numdiff"
+)
+
+// GradientDescent (Steepest descent with a backtracking Armijo line search)
+// input:
+// the function to find the minimum for (f), the gradient of f (grad), initial guess (x0), gradient norm tolerance (es), maximum iterations (iter)
+// output:
+// the estimated x (x), function value (fx), largest absolute gradient entry (ea), iterations done (iter)
+func GradientDescent(f func([]float64) float64, grad func([]float64) []float64, x0 []float64, es float64, maxit int) (x []float64, fx float64, ea float64, iter int, err error) {
+    if es < 0.0 {
+        return nil, 0.0, 0.0, 0, errors.New("es must be greater than 0")
+    }
+    if len(x0) == 0 {
+        return nil, 0.0, 0.0, 0, errors.New("x0 must not be empty")
+    }
+    x = append([]float64(nil), x0...)
+    fx = f(x)
+    g := grad(x)
+    ea = norminf(g)
+    alpha := 1.0
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        if ea <= es {
+            break
+        }
+        slope := -dot(g, g)
+        alpha *= 2.0
+        var xnew []float64
+        var fnew float64
+        found := false
+        for k := 0; k < 60; k++ {
+            xnew = axpy(x, -alpha, g)
+            fnew = f(xnew)
+            if fnew <= fx + 1e-4*alpha*slope {
+                found = true
+                break
+            }
+            alpha *= 0.5
+        }
+        if !found {
+            return x, fx, ea, iter, errors.New("No step with sufficient decrease found")
+        }
+        x, fx = xnew, fnew
+        g = grad(x)
+        ea = norminf(g)
+    }
+    return x, fx, ea, iter, nil
+}
+
+// GradientDescentLineSearch (Steepest descent with a pluggable line search)
+// input:
+// the function to find the minimum for (f), the gradient of f (grad, nil uses central differences), initial guess (x0), line search (ls, nil uses the default), gradient norm tolerance (es), maximum iterations (iter)
+// output:
+// the estimated x (x), function value (fx), largest absolute gradient entry (ea), iterations done (iter)
+func GradientDescentLineSearch(f func([]float64) float64, grad func([]float64) []float64, x0 []float64, ls LineSearch, es float64, maxit int) (x []float64, fx float64, ea float64, iter int, err error) {
+    grad, ls, err = checkMultivariate(f, grad, x0, ls, es)
+    if err != nil {
+        return nil, 0.0, 0.0, 0, err
+    }
+    x = append([]float64(nil), x0...)
+    fx = f(x)
+    g := grad(x)
+    ea = norminf(g)
+    d := make([]float64, len(x))
+    alpha := firstStep(g)/2.0
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        if ea <= es {
+            break
+        }
+        for i := range d {
+            d[i] = -g[i]
+        }
+        phi, dphi, last := lineFunctions(f, grad, x, d)
+        var fnew float64
+        alpha, fnew, err = ls(phi, dphi, fx, -dot(g, g), 2.0*alpha) // try a longer step than the last accepted one
+        if err != nil {
+            return x, fx, ea, iter, err
+        }
+        x, fx, g = axpy(x, alpha, d), fnew, last(alpha)
+        ea = norminf(g)
+    }
+    return x, fx, ea, iter, nil
+}
+
+// fdGradient returns the central difference gradient of numdiff, used when no gradient is supplied
+func fdGradient(f func([]float64) float64) func([]float64) []float64 {
+    return func(x []float64) []float64 {
+        g, _ := numdiff.Gradient(f, x) // the minimizers reject an empty x0, the only failure
+        return g
+    }
+}
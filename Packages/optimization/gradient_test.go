@@ -0,0 +1,60 @@
+package optimization
+
+import (
+    "testing"
+    "errors"
+    "fmt"
+    "math"
+)
+
+// TestGradientDescent calls optimization.GradientDescent with a quadratic function, its gradient, an initial guess,
+// error limit and max iterations, checking for a valid return value.
+func TestGradientDescent(t *testing.T) {
+    x0 := []float64{5.0, 5.0}
+    es := 1e-6
+    maxit := 1000
+    f := func(x []float64) float64 {
+        return (x[0] - 1.0)*(x[0] - 1.0) + 4.0*(x[1] + 2.0)*(x[1] + 2.0)
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{2.0*(x[0] - 1.0), 8.0*(x[1] + 2.0)}
+    }
+    xwant := []float64{1.0, -2.0}
+    fxwant := 0.0
+    x, fx, ea, iter, err := GradientDescent(f, grad, x0, es, maxit)
+    msg := fmt.Sprintf("%v, %f, %f, %d", x, fx, ea, iter)
+    want := fmt.Sprintf("%v, %f, %f, %d < %d", xwant, fxwant, es, iter, maxit)
+    if err != nil || math.Abs(x[0] - xwant[0]) > 1e-5 || math.Abs(x[1] - xwant[1]) > 1e-5 || math.Abs(fx - fxwant) > es || ea > es || iter >= maxit {
+        t.Fatalf(`GradientDescent(f, grad, [5, 5], 1e-6, 1000) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestGradientDescentEmpty calls optimization.GradientDescent with an empty initial guess,
+// checking for an error.
+func TestGradientDescentEmpty(t *testing.T) {
+    f := func(x []float64) float64 {
+        return 0.0
+    }
+    grad := func(x []float64) []float64 {
+        return nil
+    }
+    x, fx, ea, iter, err := GradientDescent(f, grad, nil, 1e-6, 10)
+    if x != nil || fx != 0.0 || ea != 0.0 || iter != 0 || err == nil {
+        t.Fatalf(`GradientDescent(f, grad, nil, 1e-6, 10) = %v, %f, %f, %d, %v, want nil, 0, 0, 0, error`, x, fx, ea, iter, err)
+    }
+}
+
+// TestGradientDescentLineSearch calls optimization.GradientDescentLineSearch with a line search that always fails,
+// checking that the failure is returned as an error.
+func TestGradientDescentLineSearch(t *testing.T) {
+    f := func(x []float64) float64 {
+        return (x[0] - 1.0)*(x[0] - 1.0) + 4.0*(x[1] + 2.0)*(x[1] + 2.0)
+    }
+    fail := func(phi func(float64) float64, dphi func(float64) float64, phi0 float64, dphi0 float64, alpha0 float64) (float64, float64, error) {
+        return 0.0, 0.0, errors.New("no step")
+    }
+    x, fx, ea, iter, err := GradientDescentLineSearch(f, nil, []float64{5.0, 5.0}, fail, 1e-6, 1000)
+    if err == nil {
+        t.Fatalf(`GradientDescentLineSearch(f, nil, [5, 5], fail, 1e-6, 1000) = %v, %g, %g, %d, nil, want error`, x, fx, ea, iter)
+    }
+}
@@ -0,0 +1,193 @@
+package optimization
+
+import (
+    "errors"
+    "math"
+    "example.com/numdiff"
+)
+
+// FitStats holds goodness-of-fit statistics and parameter uncertainties of a least squares fit
+type FitStats struct {
+    Chi2        float64     // sum of squared residuals
+    DOF         int         // degrees of freedom, number of residuals minus number of parameters
+    ReducedChi2 float64     // Chi2/DOF, an estimate of the residual variance
+    RMSE        float64     // root mean squared residual
+    Covariance  [][]float64 // parameter covariance ReducedChi2*(J'J)^-1
+    StdErr      []float64   // standard errors of the parameters, the square roots of the covariance diagonal
+}
+
+// fdJacobian returns the central difference Jacobian of numdiff, used when no Jacobian is supplied
+func fdJacobian(r func([]float64) []float64) func([]float64) [][]float64 {
+    return func(x []float64) [][]float64 {
+        J, _ := numdiff.Jacobian(r, x) // fails only for an empty x or residuals of varying length
+        return J
+    }
+}
+
+// normalEquations returns J'J and J'r
+func normalEquations(J [][]float64, res []float64, p int) ([][]float64, []float64) {
+    A := make([][]float64, p)
+    for i := range A {
+        A[i] = make([]float64, p)
+    }
+    g := make([]float64, p)
+    for k, row := range J {
+        for i := 0; i < p; i++ {
+            g[i] += row[i]*res[k]
+            for j := 0; j <= i; j++ {
+                A[i][j] += row[i]*row[j]
+            }
+        }
+    }
+    for i := 0; i < p; i++ {
+        for j := 0; j < i; j++ {
+            A[j][i] = A[i][j]
+        }
+    }
+    return A, g
+}
+
+// LevenbergMarquardt (Levenberg-Marquardt nonlinear least squares)
+// Minimizes the sum of squared residuals with Marquardt's diagonal scaling and Nielsen's damping update,
+// optionally adding the geodesic acceleration correction of Transtrum and Sethna to each step
+// input:
+// the residual function (r), the Jacobian of r with jac[i][j] = dri/dthetaj (jac, nil uses central differences), initial parameters (theta0), use geodesic acceleration (geodesic), gradient norm tolerance (es), maximum iterations (iter)
+// output:
+// the estimated parameters (theta), sum of squared residuals (fx), largest absolute entry of J'r (ea), iterations done (iter)
+func LevenbergMarquardt(r func([]float64) []float64, jac func([]float64) [][]float64, theta0 []float64, geodesic bool, es float64, maxit int) (theta []float64, fx float64, ea float64, iter int, err error) {
+    if es < 0.0 {
+        return nil, 0.0, 0.0, 0, errors.New("es must be greater than 0")
+    }
+    if len(theta0) == 0 {
+        return nil, 0.0, 0.0, 0, errors.New("theta0 must not be empty")
+    }
+    if jac == nil {
+        jac = fdJacobian(r)
+    }
+    p := len(theta0)
+    theta = append([]float64(nil), theta0...)
+    res := r(theta)
+    if len(res) < p {
+        return nil, 0.0, 0.0, 0, errors.New("The number of residuals must be at least the number of parameters")
+    }
+    fx = dot(res, res)
+    J := jac(theta)
+    A, g := normalEquations(J, res, p)
+    ea = norminf(g)
+    D := make([]float64, p)
+    lambda := 0.0
+    for i := range D {
+        D[i] = math.Max(A[i][i], 1e-12)
+        lambda = math.Max(lambda, A[i][i])
+    }
+    lambda *= 1e-3
+    nu := 2.0
+    M := make([][]float64, p)
+    for i := range M {
+        M[i] = make([]float64, p)
+    }
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        if ea <= es || lambda > 1e32 {
+            break
+        }
+        for i := range M {
+            copy(M[i], A[i])
+            M[i][i] += lambda*D[i]
+        }
+        L, ok := cholesky(M)
+        if !ok {
+            lambda *= nu
+            nu *= 2.0
+            continue
+        }
+        v := choleskySolve(L, g)
+        for i := range v {
+            v[i] = -v[i]
+        }
+        step := v
+        if geodesic { // second directional derivative of r along v from finite differences
+            h := 0.1
+            rh := r(axpy(theta, h, v))
+            Jv := matvec(J, v)
+            rvv := make([]float64, len(res))
+            for k := range rvv {
+                rvv[k] = 2.0/h*((rh[k] - res[k])/h - Jv[k])
+            }
+            Jrvv := make([]float64, p)
+            for k, row := range J {
+                for i := 0; i < p; i++ {
+                    Jrvv[i] += row[i]*rvv[k]
+                }
+            }
+            a := choleskySolve(L, Jrvv)
+            if math.Sqrt(dot(a, a)) <= 0.75*math.Sqrt(dot(v, v)) { // only accept small accelerations
+                step = axpy(v, -0.5, a)
+            }
+        }
+        thetanew := axpy(theta, 1.0, step)
+        resnew := r(thetanew)
+        fnew := dot(resnew, resnew)
+        pred := -dot(g, step) - 0.5*dot(step, matvec(A, step)) // predicted decrease of fx/2
+        rho := 0.5*(fx - fnew)/pred
+        if pred > 0.0 && rho > 0.0 && !math.IsNaN(fnew) {
+            theta, res, fx = thetanew, resnew, fnew
+            J = jac(theta)
+            A, g = normalEquations(J, res, p)
+            ea = norminf(g)
+            for i := range D {
+                D[i] = math.Max(D[i], A[i][i])
+            }
+            lambda *= math.Max(1.0/3.0, 1.0 - math.Pow(2.0*rho - 1.0, 3.0))
+            nu = 2.0
+        } else {
+            lambda *= nu
+            nu *= 2.0
+        }
+    }
+    return theta, fx, ea, iter, nil
+}
+
+// FitStatistics
+// input:
+// the residual function (r), the Jacobian of r (jac, nil uses central differences), the fitted parameters (theta)
+// output:
+// the goodness-of-fit statistics and the parameter covariance (stats)
+func FitStatistics(r func([]float64) []float64, jac func([]float64) [][]float64, theta []float64) (stats FitStats, err error) {
+    if len(theta) == 0 {
+        return stats, errors.New("theta must not be empty")
+    }
+    if jac == nil {
+        jac = fdJacobian(r)
+    }
+    p := len(theta)
+    res := r(theta)
+    stats.DOF = len(res) - p
+    if stats.DOF <= 0 {
+        return FitStats{}, errors.New("The number of residuals must be greater than the number of parameters")
+    }
+    stats.Chi2 = dot(res, res)
+    stats.ReducedChi2 = stats.Chi2/float64(stats.DOF)
+    stats.RMSE = math.Sqrt(stats.Chi2/float64(len(res)))
+    A, _ := normalEquations(jac(theta), res, p)
+    L, ok := cholesky(A)
+    if !ok {
+        return FitStats{}, errors.New("J'J is singular, the parameters are not identifiable")
+    }
+    stats.Covariance = make([][]float64, p)
+    stats.StdErr = make([]float64, p)
+    e := make([]float64, p)
+    for j := 0; j < p; j++ {
+        e[j] = 1.0
+        col := choleskySolve(L, e)
+        e[j] = 0.0
+        stats.Covariance[j] = make([]float64, p)
+        for i := range col {
+            stats.Covariance[j][i] = stats.ReducedChi2*col[i]
+        }
+    }
+    for i := range stats.StdErr {
+        stats.StdErr[i] = math.Sqrt(stats.Covariance[i][i])
+    }
+    return stats, nil
+}
@@ -0,0 +1,100 @@
+package optimization
+
+import (
+    "testing"
+    "fmt"
+    "math"
+)
+
+// TestLevenbergMarquardt calls optimization.LevenbergMarquardt with the residuals of y = a*exp(-b*t) + c on perturbed
+// data, their Jacobian, initial parameters, error limit and max iterations, with and without geodesic acceleration,
+// checking for a valid return value.
+func TestLevenbergMarquardt(t *testing.T) {
+    ts := make([]float64, 40)
+    ys := make([]float64, 40)
+    for i := range ts {
+        ts[i] = 0.25*float64(i)
+        ys[i] = 5.0*math.Exp(-0.7*ts[i]) + 1.5 + 0.01*math.Sin(7.0*float64(i))
+    }
+    r := func(theta []float64) []float64 {
+        res := make([]float64, len(ts))
+        for i := range res {
+            res[i] = theta[0]*math.Exp(-theta[1]*ts[i]) + theta[2] - ys[i]
+        }
+        return res
+    }
+    jac := func(theta []float64) [][]float64 {
+        J := make([][]float64, len(ts))
+        for i := range J {
+            e := math.Exp(-theta[1]*ts[i])
+            J[i] = []float64{e, -theta[0]*ts[i]*e, 1.0}
+        }
+        return J
+    }
+    theta0 := []float64{1.0, 2.0, 0.0}
+    es := 1e-10
+    maxit := 200
+    thetawant := []float64{5.0, 0.7, 1.5}
+    for _, geodesic := range []bool{false, true} {
+        theta, fx, ea, iter, err := LevenbergMarquardt(r, jac, theta0, geodesic, es, maxit)
+        msg := fmt.Sprintf("%v, %g, %g, %d", theta, fx, ea, iter)
+        want := fmt.Sprintf("%v, %g, %g, %d < %d", thetawant, 0.0, es, iter, maxit)
+        if err != nil || ea > es || iter >= maxit || fx > 40*1e-4 {
+            t.Fatalf(`LevenbergMarquardt(r, jac, [1, 2, 0], %t, 1e-10, 200) = %q, %v, want match for %#v, nil`, geodesic, msg, err, want)
+        }
+        for i := range theta {
+            if math.Abs(theta[i] - thetawant[i]) > 0.01 {
+                t.Fatalf(`LevenbergMarquardt(r, jac, [1, 2, 0], %t, 1e-10, 200) = %q, %v, want match for %#v, nil`, geodesic, msg, err, want)
+            }
+        }
+    }
+}
+
+// TestLevenbergMarquardtFiniteDifference calls optimization.LevenbergMarquardt without a Jacobian on the
+// Rosenbrock residuals (10(x2 - x1^2), 1 - x1), checking that the zero residual solution is found.
+func TestLevenbergMarquardtFiniteDifference(t *testing.T) {
+    r := func(x []float64) []float64 {
+        return []float64{10.0*(x[1] - x[0]*x[0]), 1.0 - x[0]}
+    }
+    x, fx, ea, iter, err := LevenbergMarquardt(r, nil, []float64{-1.2, 1.0}, false, 1e-10, 200)
+    if err != nil || math.Abs(x[0] - 1.0) > 1e-8 || math.Abs(x[1] - 1.0) > 1e-8 || fx > 1e-16 {
+        t.Fatalf(`LevenbergMarquardt(r, nil, [-1.2, 1], false, 1e-10, 200) = %v, %g, %g, %d, %v, want [1, 1]`, x, fx, ea, iter, err)
+    }
+}
+
+// TestFitStatistics calls optimization.FitStatistics for a straight line fit, checking the statistics against
+// the closed form results of linear regression.
+func TestFitStatistics(t *testing.T) {
+    ts := []float64{0.0, 1.0, 2.0, 3.0, 4.0}
+    ys := []float64{1.1, 2.9, 5.2, 6.8, 9.1}
+    r := func(theta []float64) []float64 {
+        res := make([]float64, len(ts))
+        for i := range res {
+            res[i] = theta[0] + theta[1]*ts[i] - ys[i]
+        }
+        return res
+    }
+    theta, _, _, _, err := LevenbergMarquardt(r, nil, []float64{0.0, 0.0}, false, 1e-12, 100)
+    if err != nil {
+        t.Fatalf(`LevenbergMarquardt(line, nil, [0, 0], false, 1e-12, 100) = %v, want nil`, err)
+    }
+    stats, err := FitStatistics(r, nil, theta)
+    // closed form: slope 1.99, intercept 1.04, chi2 0.107, var(slope) = s^2/sum (t - tmean)^2
+    s2 := 0.107/3.0
+    if err != nil || stats.DOF != 3 || math.Abs(theta[1] - 1.99) > 1e-8 || math.Abs(theta[0] - 1.04) > 1e-8 ||
+        math.Abs(stats.Chi2 - 0.107) > 1e-8 || math.Abs(stats.ReducedChi2 - s2) > 1e-8 ||
+        math.Abs(stats.StdErr[1] - math.Sqrt(s2/10.0)) > 1e-6 || math.Abs(stats.StdErr[0] - math.Sqrt(s2*(1.0/5.0 + 4.0/10.0))) > 1e-6 {
+        t.Fatalf(`FitStatistics(line, nil, %v) = %+v, %v`, theta, stats, err)
+    }
+}
+
+// TestFitStatisticsDOF calls optimization.FitStatistics with as many parameters as residuals, checking for an error.
+func TestFitStatisticsDOF(t *testing.T) {
+    r := func(theta []float64) []float64 {
+        return []float64{theta[0] - 1.0}
+    }
+    stats, err := FitStatistics(r, nil, []float64{1.0})
+    if err == nil {
+        t.Fatalf(`FitStatistics(r, nil, [1]) = %+v, %v, want error`, stats, err)
+    }
+}
@@ -0,0 +1,156 @@
+package optimization
+
+import (
+    "math"
+)
+
+// matvec returns A x
+func matvec(A [][]float64, x []float64) []float64 {
+    y := make([]float64, len(A))
+    for i := range A {
+        y[i] = dot(A[i], x)
+    }
+    return y
+}
+
+// cholesky returns the lower triangular L with A = L L', ok is false when A is not positive definite
+func cholesky(A [][]float64) (L [][]float64, ok bool) {
+    n := len(A)
+    L = make([][]float64, n)
+    for i := range L {
+        L[i] = make([]float64, n)
+    }
+    for j := 0; j < n; j++ {
+        s := A[j][j]
+        for k := 0; k < j; k++ {
+            s -= L[j][k]*L[j][k]
+        }
+        if s <= 0.0 || math.IsNaN(s) {
+            return nil, false
+        }
+        L[j][j] = math.Sqrt(s)
+        for i := j + 1; i < n; i++ {
+            s = A[i][j]
+            for k := 0; k < j; k++ {
+                s -= L[i][k]*L[j][k]
+            }
+            L[i][j] = s/L[j][j]
+        }
+    }
+    return L, true
+}
+
+// choleskySolve solves L L' x = b
+func choleskySolve(L [][]float64, b []float64) []float64 {
+    n := len(L)
+    x := append([]float64(nil), b...)
+    for i := 0; i < n; i++ {
+        for k := 0; k < i; k++ {
+            x[i] -= L[i][k]*x[k]
+        }
+        x[i] /= L[i][i]
+    }
+    for i := n - 1; i >= 0; i-- {
+        for k := i + 1; k < n; k++ {
+            x[i] -= L[k][i]*x[k]
+        }
+        x[i] /= L[i][i]
+    }
+    return x
+}
+
+// modifiedCholesky factors A + tau I for the smallest tau from the sequence 0, beta, 2 beta, ... that
+// makes the matrix positive definite (Cholesky with added multiple of the identity), L is nil if no such tau is found
+func modifiedCholesky(A [][]float64) (L [][]float64, tau float64) {
+    n := len(A)
+    beta := 0.0
+    mindiag := math.Inf(1)
+    for i := 0; i < n; i++ {
+        for j := 0; j < n; j++ {
+            beta += A[i][j]*A[i][j]
+        }
+        mindiag = math.Min(mindiag, A[i][i])
+    }
+    beta = 1e-3*math.Max(math.Sqrt(beta), 1.0)
+    if mindiag <= 0.0 {
+        tau = -mindiag + beta
+    }
+    B := make([][]float64, n)
+    for i := range B {
+        B[i] = append([]float64(nil), A[i]...)
+    }
+    for k := 0; k < 100; k++ {
+        for i := range B {
+            B[i][i] = A[i][i] + tau
+        }
+        if L, ok := cholesky(B); ok {
+            return L, tau
+        }
+        tau = math.Max(2.0*tau, beta)
+    }
+    return nil, tau
+}
+
+// symmetricEigen returns the eigenvalues of the symmetric matrix A in increasing order and the matching eigenvectors
+// as the columns of V, computed with cyclic Jacobi rotations
+func symmetricEigen(A [][]float64) (values []float64, V [][]float64) {
+    n := len(A)
+    S := make([][]float64, n)
+    V = make([][]float64, n)
+    for i := range S {
+        S[i] = append([]float64(nil), A[i]...)
+        V[i] = make([]float64, n)
+        V[i][i] = 1.0
+    }
+    for sweep := 0; sweep < 100; sweep++ {
+        off := 0.0
+        for i := 0; i < n; i++ {
+            for j := i + 1; j < n; j++ {
+                off += S[i][j]*S[i][j]
+            }
+        }
+        if off == 0.0 {
+            break
+        }
+        for p := 0; p < n; p++ {
+            for q := p + 1; q < n; q++ {
+                if S[p][q] == 0.0 {
+                    continue
+                }
+                theta := (S[q][q] - S[p][p])/(2.0*S[p][q])
+                t := 1.0/(math.Abs(theta) + math.Sqrt(theta*theta + 1.0))
+                if theta < 0.0 {
+                    t = -t
+                }
+                c := 1.0/math.Sqrt(t*t + 1.0)
+                s := t*c
+                for k := 0; k < n; k++ { // S = S J
+                    skp, skq := S[k][p], S[k][q]
+                    S[k][p], S[k][q] = c*skp - s*skq, s*skp + c*skq
+                }
+                for k := 0; k < n; k++ { // S = J' S
+                    spk, sqk := S[p][k], S[q][k]
+                    S[p][k], S[q][k] = c*spk - s*sqk, s*spk + c*sqk
+                }
+                S[p][q], S[q][p] = 0.0, 0.0
+                for k := 0; k < n; k++ {
+                    vkp, vkq := V[k][p], V[k][q]
+                    V[k][p], V[k][q] = c*vkp - s*vkq, s*vkp + c*vkq
+                }
+            }
+        }
+    }
+    values = make([]float64, n)
+    for i := range values {
+        values[i] = S[i][i]
+    }
+    for i := 1; i < n; i++ { // insertion sort of the eigenpairs
+        for j := i; j > 0 && values[j] < values[j-1]; j-- {
+            values[j], values[j-1] = values[j-1], values[j]
+            for k := range V {
+                V[k][j], V[k][j-1] = V[k][j-1], V[k][j]
+            }
+        }
+    }
+    return values, V
+}
@@ -0,0 +1,376 @@
+package optimization
+
+import (
+    "errors"
+    "math"
+)
+
+// LineSearch finds a step length alpha along a descent direction d from x
+// input:
+// the function along the direction phi(alpha) = f(x + alpha*d) (phi), its derivative dphi(alpha) = grad f(x + alpha*d)'d (dphi), phi(0) (phi0), dphi(0) < 0 (dphi0), initial step length (alpha0)
+// output:
+// the accepted step length (alpha), phi(alpha) (phia)
+type LineSearch func(phi func(float64) float64, dphi func(float64) float64, phi0 float64, dphi0 float64, alpha0 float64) (alpha float64, phia float64, err error)
+
+// Backtracking (Backtracking line search with the Armijo condition)
+// input:
+// sufficient decrease constant in (0, 1) (c1), step reduction factor in (0, 1) (rho)
+// output:
+// a line search that shrinks alpha by rho until phi(alpha) <= phi(0) + c1*alpha*dphi(0)
+func Backtracking(c1 float64, rho float64) LineSearch {
+    return func(phi func(float64) float64, dphi func(float64) float64, phi0 float64, dphi0 float64, alpha0 float64) (alpha float64, phia float64, err error) {
+        if c1 <= 0.0 || c1 >= 1.0 || rho <= 0.0 || rho >= 1.0 {
+            return 0.0, 0.0, errors.New("c1 and rho must be in (0, 1)")
+        }
+        if dphi0 >= 0.0 {
+            return 0.0, 0.0, errors.New("d is not a descent direction")
+        }
+        alpha = alpha0
+        for k := 0; k < 100; k++ {
+            phia = phi(alpha)
+            if phia <= phi0 + c1*alpha*dphi0 {
+                return alpha, phia, nil
+            }
+            alpha *= rho
+        }
+        return 0.0, 0.0, errors.New("No step with sufficient decrease found")
+    }
+}
+
+// lineFunctions returns phi and dphi for the ray x + alpha*d, the gradient from the latest dphi evaluation is
+// kept so the minimizers can reuse it at the accepted point
+func lineFunctions(f func([]float64) float64, grad func([]float64) []float64, x []float64, d []float64) (phi func(float64) float64, dphi func(float64) float64, last func(float64) []float64) {
+    lastalpha := 0.0
+    var lastgrad []float64
+    phi = func(alpha float64) float64 {
+        return f(axpy(x, alpha, d))
+    }
+    dphi = func(alpha float64) float64 {
+        g := grad(axpy(x, alpha, d))
+        lastalpha, lastgrad = alpha, g
+        return dot(g, d)
+    }
+    last = func(alpha float64) []float64 {
+        if lastgrad != nil && lastalpha == alpha {
+            return lastgrad
+        }
+        return grad(axpy(x, alpha, d))
+    }
+    return phi, dphi, last
+}
+
+// cstep computes a safeguarded step for MoreThuente and updates the interval of uncertainty [stx, sty],
+// (stx, fx, dx) is the best step so far, (sty, fy, dy) the other endpoint and (stp, fp, dp) the current step
+func cstep(stx, fx, dx, sty, fy, dy, stp, fp, dp float64, brackt bool, stpmin, stpmax float64) (float64, float64, float64, float64, float64, float64, float64, bool) {
+    var stpf, stpc, stpq, theta, s, gamma, p, q, r float64
+    sgnd := dp*math.Copysign(1.0, dx)
+    if fp > fx { // higher function value, the minimum is bracketed
+        theta = 3.0*(fx - fp)/(stp - stx) + dx + dp
+        s = math.Max(math.Abs(theta), math.Max(math.Abs(dx), math.Abs(dp)))
+        gamma = s*math.Sqrt(math.Max(0.0, (theta/s)*(theta/s) - (dx/s)*(dp/s)))
+        if stp < stx {
+            gamma = -gamma
+        }
+        p = (gamma - dx) + theta
+        q = ((gamma - dx) + gamma) + dp
+        r = p/q
+        stpc = stx + r*(stp - stx)
+        stpq = stx + ((dx/((fx - fp)/(stp - stx) + dx))/2.0)*(stp - stx)
+        if math.Abs(stpc - stx) < math.Abs(stpq - stx) {
+            stpf = stpc
+        } else {
+            stpf = stpc + (stpq - stpc)/2.0
+        }
+        brackt = true
+    } else if sgnd < 0.0 { // derivatives of opposite sign, the minimum is bracketed
+        theta = 3.0*(fx - fp)/(stp - stx) + dx + dp
+        s = math.Max(math.Abs(theta), math.Max(math.Abs(dx), math.Abs(dp)))
+        gamma = s*math.Sqrt(math.Max(0.0, (theta/s)*(theta/s) - (dx/s)*(dp/s)))
+        if stp > stx {
+            gamma = -gamma
+        }
+        p = (gamma - dp) + theta
+        q = ((gamma - dp) + gamma) + dx
+        r = p/q
+        stpc = stp + r*(stx - stp)
+        stpq = stp + (dp/(dp - dx))*(stx - stp)
+        if math.Abs(stpc - stp) > math.Abs(stpq - stp) {
+            stpf = stpc
+        } else {
+            stpf = stpq
+        }
+        brackt = true
+    } else if math.Abs(dp) < math.Abs(dx) { // derivative decreases in magnitude
+        theta = 3.0*(fx - fp)/(stp - stx) + dx + dp
+        s = math.Max(math.Abs(theta), math.Max(math.Abs(dx), math.Abs(dp)))
+        gamma = s*math.Sqrt(math.Max(0.0, (theta/s)*(theta/s) - (dx/s)*(dp/s)))
+        if stp > stx {
+            gamma = -gamma
+        }
+        p = (gamma - dp) + theta
+        q = (gamma + (dx - dp)) + gamma
+        r = p/q
+        if r < 0.0 && gamma != 0.0 {
+            stpc = stp + r*(stx - stp)
+        } else if stp > stx {
+            stpc = stpmax
+        } else {
+            stpc = stpmin
+        }
+        stpq = stp + (dp/(dp - dx))*(stx - stp)
+        if brackt {
+            if math.Abs(stpc - stp) < math.Abs(stpq - stp) {
+                stpf = stpc
+            } else {
+                stpf = stpq
+            }
+            if stp > stx {
+                stpf = math.Min(stp + 0.66*(sty - stp), stpf)
+            } else {
+                stpf = math.Max(stp + 0.66*(sty - stp), stpf)
+            }
+        } else {
+            if math.Abs(stpc - stp) > math.Abs(stpq - stp) {
+                stpf = stpc
+            } else {
+                stpf = stpq
+            }
+            stpf = math.Max(stpmin, math.Min(stpmax, stpf))
+        }
+    } else { // derivative does not decrease in magnitude
+        if brackt {
+            theta = 3.0*(fp - fy)/(sty - stp) + dy + dp
+            s = math.Max(math.Abs(theta), math.Max(math.Abs(dy), math.Abs(dp)))
+            gamma = s*math.Sqrt(math.Max(0.0, (theta/s)*(theta/s) - (dy/s)*(dp/s)))
+            if stp > sty {
+                gamma = -gamma
+            }
+            p = (gamma - dp) + theta
+            q = ((gamma - dp) + gamma) + dy
+            r = p/q
+            stpf = stp + r*(sty - stp)
+        } else if stp > stx {
+            stpf = stpmax
+        } else {
+            stpf = stpmin
+        }
+    }
+    if fp > fx {
+        sty, fy, dy = stp, fp, dp
+    } else {
+        if sgnd < 0.0 {
+            sty, fy, dy = stx, fx, dx
+        }
+        stx, fx, dx = stp, fp, dp
+    }
+    return stx, fx, dx, sty, fy, dy, stpf, brackt
+}
+
+// MoreThuente (More-Thuente line search for the strong Wolfe conditions)
+// input:
+// sufficient decrease constant (c1), curvature constant with c1 < c2 < 1 (c2)
+// output:
+// a line search returning a step with phi(alpha) <= phi(0) + c1*alpha*dphi(0) and |dphi(alpha)| <= c2*|dphi(0)|
+func MoreThuente(c1 float64, c2 float64) LineSearch {
+    return func(phi func(float64) float64, dphi func(float64) float64, phi0 float64, dphi0 float64, alpha0 float64) (alpha float64, phia float64, err error) {
+        if c1 <= 0.0 || c2 <= c1 || c2 >= 1.0 {
+            return 0.0, 0.0, errors.New("0 < c1 < c2 < 1 must hold")
+        }
+        if dphi0 >= 0.0 {
+            return 0.0, 0.0, errors.New("d is not a descent direction")
+        }
+        const xtol = 1e-12
+        const stpmin = 0.0
+        const stpmax = 1e20
+        const xtrapl = 1.1
+        const xtrapu = 4.0
+        stp := alpha0
+        brackt := false
+        stage := 1
+        gtest := c1*dphi0
+        width := stpmax - stpmin
+        width1 := 2.0*width
+        stx, fx, gx := 0.0, phi0, dphi0
+        sty, fy, gy := 0.0, phi0, dphi0
+        stmin := 0.0
+        stmax := stp + xtrapu*stp
+        var f, g, ftest float64
+        for k := 0; k < 40; k++ {
+            f = phi(stp)
+            g = dphi(stp)
+            ftest = phi0 + stp*gtest
+            if stage == 1 && f <= ftest && g >= 0.0 {
+                stage = 2
+            }
+            if f <= ftest && math.Abs(g) <= -c2*dphi0 { // strong Wolfe conditions hold
+                return stp, f, nil
+            }
+            if (brackt && (stp <= stmin || stp >= stmax)) || (brackt && stmax - stmin <= xtol*stmax) || stp == stpmax || stp == stpmin {
+                break // rounding errors or the interval prevent further progress
+            }
+            if stage == 1 && f <= fx && f > ftest { // use the modified function psi(a) = phi(a) - phi(0) - c1*a*dphi(0)
+                var fxm, gxm, fym, gym float64
+                stx, fxm, gxm, sty, fym, gym, stp, brackt = cstep(stx, fx - stx*gtest, gx - gtest, sty, fy - sty*gtest, gy - gtest, stp, f - stp*gtest, g - gtest, brackt, stmin, stmax)
+                fx, gx = fxm + stx*gtest, gxm + gtest
+                fy, gy = fym + sty*gtest, gym + gtest
+            } else {
+                stx, fx, gx, sty, fy, gy, stp, brackt = cstep(stx, fx, gx, sty, fy, gy, stp, f, g, brackt, stmin, stmax)
+            }
+            if brackt {
+                if math.Abs(sty - stx) >= 0.66*width1 { // force sufficient shrinking of the interval
+                    stp = stx + 0.5*(sty - stx)
+                }
+                width1 = width
+                width = math.Abs(sty - stx)
+                stmin = math.Min(stx, sty)
+                stmax = math.Max(stx, sty)
+            } else {
+                stmin = stp + xtrapl*(stp - stx)
+                stmax = stp + xtrapu*(stp - stx)
+            }
+            stp = math.Max(stpmin, math.Min(stpmax, stp))
+            if (brackt && (stp <= stmin || stp >= stmax)) || (brackt && stmax - stmin <= xtol*stmax) {
+                stp = stx
+            }
+        }
+        if stx > 0.0 && fx <= phi0 + stx*gtest { // fall back to the best step found
+            return stx, phi(stx), nil
+        }
+        return 0.0, 0.0, errors.New("No step satisfying the Wolfe conditions found")
+    }
+}
+
+// HagerZhang (Hager-Zhang line search for the approximate Wolfe conditions)
+// Brackets the step and shrinks the bracket with double secant steps, accepting a step that satisfies either the
+// Wolfe conditions or the approximate Wolfe conditions (2*c1 - 1)*dphi(0) >= dphi(alpha) >= c2*dphi(0),
+// phi(alpha) <= phi(0) + 1e-6*|phi(0)|, which are numerically robust close to the minimum
+// input:
+// sufficient decrease constant with 0 < c1 < 0.5 (c1), curvature constant with c1 <= c2 < 1 (c2)
+// output:
+// a line search returning a step satisfying the (approximate) Wolfe conditions
+func HagerZhang(c1 float64, c2 float64) LineSearch {
+    return func(phi func(float64) float64, dphi func(float64) float64, phi0 float64, dphi0 float64, alpha0 float64) (alpha float64, phia float64, err error) {
+        if c1 <= 0.0 || c1 >= 0.5 || c2 < c1 || c2 >= 1.0 {
+            return 0.0, 0.0, errors.New("0 < c1 < 0.5 and c1 <= c2 < 1 must hold")
+        }
+        if dphi0 >= 0.0 {
+            return 0.0, 0.0, errors.New("d is not a descent direction")
+        }
+        const theta = 0.5
+        const gamma = 0.66
+        const expand = 5.0
+        const maxeval = 60
+        epsk := 1e-6*math.Abs(phi0)
+        done := false
+        evals := 0
+        // eval returns phi and dphi at c and records c as the answer if it satisfies the termination conditions
+        eval := func(c float64) (float64, float64) {
+            fc, dc := phi(c), dphi(c)
+            evals++
+            wolfe := fc <= phi0 + c1*c*dphi0 && dc >= c2*dphi0
+            approx := (2.0*c1 - 1.0)*dphi0 >= dc && dc >= c2*dphi0 && fc <= phi0 + epsk
+            if !done && (wolfe || approx) {
+                done = true
+                alpha, phia = c, fc
+            }
+            return fc, dc
+        }
+        // bisect shrinks [a, b] where dphi(a) < 0 and phi(b) > phi(0) + epsk until it brackets a minimizer
+        bisect := func(a, da, b, db float64) (float64, float64, float64, float64) {
+            for !done && evals < maxeval {
+                d := (1.0 - theta)*a + theta*b
+                fd, dd := eval(d)
+                if dd >= 0.0 {
+                    return a, da, d, dd
+                }
+                if fd <= phi0 + epsk {
+                    a, da = d, dd
+                } else {
+                    b, db = d, dd
+                }
+            }
+            return a, da, b, db
+        }
+        // update places c in the bracket [a, b] keeping dphi(a) < 0 <= dphi(b)
+        update := func(a, da, b, db, c, fc, dc float64) (float64, float64, float64, float64) {
+            if c <= a || c >= b {
+                return a, da, b, db
+            }
+            if dc >= 0.0 {
+                return a, da, c, dc
+            }
+            if fc <= phi0 + epsk {
+                return c, dc, b, db
+            }
+            return bisect(a, da, c, dc)
+        }
+        secant := func(a, da, b, db float64) float64 {
+            if db == da {
+                return 0.5*(a + b)
+            }
+            return (a*db - b*da)/(db - da)
+        }
+        // Initial bracket
+        a, da := 0.0, dphi0
+        c := alpha0
+        var b, db float64
+        for {
+            if evals >= maxeval {
+                return 0.0, 0.0, errors.New("No bracket for the Wolfe conditions found")
+            }
+            fc, dc := eval(c)
+            if done {
+                return alpha, phia, nil
+            }
+            if dc >= 0.0 {
+                b, db = c, dc
+                break
+            }
+            if fc > phi0 + epsk {
+                a, da, b, db = bisect(a, da, c, dc)
+                break
+            }
+            a, da = c, dc
+            c *= expand
+        }
+        // Double secant steps until the conditions hold
+        for !done && evals < maxeval {
+            olda, oldb := a, b
+            c = secant(a, da, b, db)
+            fc, dc := eval(c)
+            if done {
+                break
+            }
+            na, nda, nb, ndb := update(a, da, b, db, c, fc, dc)
+            if !done && (c == na || c == nb) {
+                var cbar float64
+                if c == nb {
+                    cbar = secant(b, db, nb, ndb)
+                } else {
+                    cbar = secant(a, da, na, nda)
+                }
+                fcb, dcb := eval(cbar)
+                if done {
+                    break
+                }
+                na, nda, nb, ndb = update(na, nda, nb, ndb, cbar, fcb, dcb)
+            }
+            a, da, b, db = na, nda, nb, ndb
+            if !done && b - a > gamma*(oldb - olda) { // the secant steps did not shrink enough, bisect instead
+                c = 0.5*(a + b)
+                fc, dc = eval(c)
+                if done {
+                    break
+                }
+                a, da, b, db = update(a, da, b, db, c, fc, dc)
+            }
+            if b - a <= 1e-14*math.Max(b, 1.0) {
+                break
+            }
+        }
+        if done {
+            return alpha, phia, nil
+        }
+        return 0.0, 0.0, errors.New("No step satisfying the Wolfe conditions found")
+    }
+}
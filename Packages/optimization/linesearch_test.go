@@ -0,0 +1,84 @@
+package optimization
+
+import (
+    "testing"
+    "math"
+)
+
+// TestBacktracking calls optimization.Backtracking on a one dimensional quadratic, checking that the
+// accepted step satisfies the Armijo condition.
+func TestBacktracking(t *testing.T) {
+    c1 := 1e-4
+    phi := func(a float64) float64 {
+        return (a - 0.1)*(a - 0.1)
+    }
+    dphi := func(a float64) float64 {
+        return 2.0*(a - 0.1)
+    }
+    alpha, phia, err := Backtracking(c1, 0.5)(phi, dphi, phi(0.0), dphi(0.0), 1.0)
+    if err != nil || alpha <= 0.0 || phia > phi(0.0) + c1*alpha*dphi(0.0) || phia != phi(alpha) {
+        t.Fatalf(`Backtracking(1e-4, 0.5)(phi, dphi, 0.01, -0.2, 1) = %f, %f, %v, want Armijo step, nil`, alpha, phia, err)
+    }
+}
+
+// TestBacktrackingAscent calls optimization.Backtracking with an ascent direction, checking for an error.
+func TestBacktrackingAscent(t *testing.T) {
+    phi := func(a float64) float64 {
+        return a
+    }
+    dphi := func(a float64) float64 {
+        return 1.0
+    }
+    alpha, phia, err := Backtracking(1e-4, 0.5)(phi, dphi, 0.0, 1.0, 1.0)
+    if alpha != 0.0 || phia != 0.0 || err == nil {
+        t.Fatalf(`Backtracking(1e-4, 0.5)(phi, dphi, 0, 1, 1) = %f, %f, %v, want 0, 0, error`, alpha, phia, err)
+    }
+}
+
+// TestWolfeLineSearches calls optimization.MoreThuente and optimization.HagerZhang on the first test function of
+// More and Thuente from several initial steps, checking the returned step against the Wolfe conditions.
+func TestWolfeLineSearches(t *testing.T) {
+    beta := 2.0
+    phi := func(a float64) float64 {
+        return -a/(a*a + beta)
+    }
+    dphi := func(a float64) float64 {
+        return (a*a - beta)/((a*a + beta)*(a*a + beta))
+    }
+    c1 := 1e-3
+    c2 := 0.1
+    searches := map[string]LineSearch{"MoreThuente": MoreThuente(c1, c2), "HagerZhang": HagerZhang(c1, c2)}
+    for name, ls := range searches {
+        for _, alpha0 := range []float64{1e-3, 1e-1, 1e1, 1e3} {
+            alpha, phia, err := ls(phi, dphi, phi(0.0), dphi(0.0), alpha0)
+            wolfe := phia <= phi(0.0) + c1*alpha*dphi(0.0) && math.Abs(dphi(alpha)) <= -c2*dphi(0.0)
+            approx := name == "HagerZhang" && dphi(alpha) <= (2.0*c1 - 1.0)*dphi(0.0) && dphi(alpha) >= c2*dphi(0.0)
+            if err != nil || phia != phi(alpha) || !(wolfe || approx) {
+                t.Fatalf(`%s(%g, %g)(phi, dphi, 0, %g, %g) = %f, %f, %v, want Wolfe step, nil`, name, c1, c2, dphi(0.0), alpha0, alpha, phia, err)
+            }
+        }
+    }
+}
+
+// TestLineSearchesInMinimizers passes each line search to optimization.GradientDescentLineSearch and optimization.BFGS,
+// checking that they are interchangeable.
+func TestLineSearchesInMinimizers(t *testing.T) {
+    f := func(x []float64) float64 {
+        return (1.0 - x[0])*(1.0 - x[0]) + 100.0*(x[1] - x[0]*x[0])*(x[1] - x[0]*x[0])
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{-2.0*(1.0 - x[0]) - 400.0*x[0]*(x[1] - x[0]*x[0]), 200.0*(x[1] - x[0]*x[0])}
+    }
+    x0 := []float64{-1.2, 1.0}
+    searches := map[string]LineSearch{"Backtracking": Backtracking(1e-4, 0.5), "MoreThuente": MoreThuente(1e-4, 0.9), "HagerZhang": HagerZhang(0.1, 0.9)}
+    for name, ls := range searches {
+        x, fx, ea, iter, err := BFGS(f, grad, x0, ls, 1e-8, 500)
+        if err != nil || math.Abs(x[0] - 1.0) > 1e-6 || math.Abs(x[1] - 1.0) > 1e-6 {
+            t.Fatalf(`BFGS(rosenbrock, grad, x0, %s, 1e-8, 500) = %v, %g, %g, %d, %v, want [1, 1]`, name, x, fx, ea, iter, err)
+        }
+        x, fx, ea, iter, err = GradientDescentLineSearch(f, grad, x0, ls, 1e-3, 50000)
+        if err != nil || math.Abs(x[0] - 1.0) > 1e-2 || math.Abs(x[1] - 1.0) > 1e-2 {
+            t.Fatalf(`GradientDescentLineSearch(rosenbrock, grad, x0, %s, 1e-3, 50000) = %v, %g, %g, %d, %v, want [1, 1]`, name, x, fx, ea, iter, err)
+        }
+    }
+}
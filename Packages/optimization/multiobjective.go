@@ -0,0 +1,366 @@
+package optimization
+
+import (
+    "encoding/csv"
+    "errors"
+    "fmt"
+    "io"
+    "math"
+    "math/rand"
+    "sort"
+    "strconv"
+    "sync"
+)
+
+// Dominates reports whether the objective vector a Pareto dominates b, no component of a is larger and at least one
+// is smaller, all objectives are minimized
+func Dominates(a []float64, b []float64) bool {
+    smaller := false
+    for i := range a {
+        if a[i] > b[i] {
+            return false
+        }
+        if a[i] < b[i] {
+            smaller = true
+        }
+    }
+    return smaller
+}
+
+// ParetoFront returns the indices of the objective vectors in F that no other vector dominates, in their order in F
+func ParetoFront(F [][]float64) []int {
+    var front []int
+    for i := range F {
+        dominated := false
+        for j := range F {
+            if j != i && Dominates(F[j], F[i]) {
+                dominated = true
+                break
+            }
+        }
+        if !dominated {
+            front = append(front, i)
+        }
+    }
+    return front
+}
+
+// Hypervolume (Hypervolume indicator)
+// The volume of the region dominated by the objective vectors and bounded by the reference point, computed exactly by
+// slicing along the last objective. Vectors that do not dominate the reference point add nothing.
+// input:
+// the objective vectors (F), reference point (ref)
+// output:
+// the dominated volume (hv)
+func Hypervolume(F [][]float64, ref []float64) (hv float64, err error) {
+    if len(ref) == 0 {
+        return 0.0, errors.New("ref must not be empty")
+    }
+    var points [][]float64
+    for _, p := range F {
+        if len(p) != len(ref) {
+            return 0.0, errors.New("The vectors of F must have the length of ref")
+        }
+        inside := true
+        for i := range p {
+            inside = inside && p[i] < ref[i]
+        }
+        if inside {
+            points = append(points, p)
+        }
+    }
+    return slicedVolume(points, ref, len(ref)), nil
+}
+
+// slicedVolume returns the volume dominated by the points in their first m objectives
+func slicedVolume(points [][]float64, ref []float64, m int) float64 {
+    if len(points) == 0 {
+        return 0.0
+    }
+    if m == 1 {
+        low := ref[0]
+        for _, p := range points {
+            low = math.Min(low, p[0])
+        }
+        return ref[0] - low
+    }
+    sorted := append([][]float64(nil), points...)
+    sort.SliceStable(sorted, func(a, b int) bool { return sorted[a][m-1] < sorted[b][m-1] })
+    volume := 0.0
+    for i := range sorted {
+        next := ref[m-1]
+        if i + 1 < len(sorted) {
+            next = sorted[i+1][m-1]
+        }
+        if next > sorted[i][m-1] {
+            volume += slicedVolume(sorted[:i+1], ref, m - 1)*(next - sorted[i][m-1])
+        }
+    }
+    return volume
+}
+
+// WriteFrontCSV writes the points X and their objective vectors F as CSV with the header x0, x1, ..., f0, f1, ...
+func WriteFrontCSV(w io.Writer, X [][]float64, F [][]float64) error {
+    if len(X) != len(F) {
+        return errors.New("X and F must have the same length")
+    }
+    cw := csv.NewWriter(w)
+    if len(X) > 0 {
+        var header []string
+        for i := range X[0] {
+            header = append(header, fmt.Sprintf("x%d", i))
+        }
+        for i := range F[0] {
+            header = append(header, fmt.Sprintf("f%d", i))
+        }
+        if err := cw.Write(header); err != nil {
+            return err
+        }
+    }
+    for k := range X {
+        var record []string
+        for _, v := range append(append([]float64(nil), X[k]...), F[k]...) {
+            record = append(record, strconv.FormatFloat(v, 'g', -1, 64))
+        }
+        if err := cw.Write(record); err != nil {
+            return err
+        }
+    }
+    cw.Flush()
+    return cw.Error()
+}
+
+// evaluateObjectives returns the objective vectors of f at the points using up to workers goroutines
+func evaluateObjectives(f func([]float64) []float64, points [][]float64, workers int) [][]float64 {
+    F := make([][]float64, len(points))
+    if workers <= 1 {
+        for i, p := range points {
+            F[i] = f(p)
+        }
+        return F
+    }
+    next := make(chan int)
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range next {
+                F[i] = f(points[i])
+            }
+        }()
+    }
+    for i := range points {
+        next <- i
+    }
+    close(next)
+    wg.Wait()
+    return F
+}
+
+// nondominatedSort splits the objective vectors into fronts, front k is dominated only by vectors of the fronts before
+// it, rank holds the front of every vector
+func nondominatedSort(F [][]float64) (fronts [][]int, rank []int) {
+    rank = make([]int, len(F))
+    count := make([]int, len(F))
+    dominated := make([][]int, len(F))
+    var front []int
+    for i := range F {
+        for j := range F {
+            if Dominates(F[i], F[j]) {
+                dominated[i] = append(dominated[i], j)
+            } else if Dominates(F[j], F[i]) {
+                count[i]++
+            }
+        }
+        if count[i] == 0 {
+            front = append(front, i)
+        }
+    }
+    for len(front) > 0 {
+        fronts = append(fronts, front)
+        var next []int
+        for _, i := range front {
+            for _, j := range dominated[i] {
+                count[j]--
+                if count[j] == 0 {
+                    rank[j] = len(fronts)
+                    next = append(next, j)
+                }
+            }
+        }
+        front = next
+    }
+    return fronts, rank
+}
+
+// crowding returns the crowding distance of the vectors of a front, the normalized perimeter of the box spanned by
+// their neighbours in every objective, infinite at the extremes
+func crowding(F [][]float64, front []int) map[int]float64 {
+    distance := make(map[int]float64, len(front))
+    for _, i := range front {
+        distance[i] = 0.0
+    }
+    sorted := append([]int(nil), front...)
+    for m := range F[front[0]] {
+        sort.SliceStable(sorted, func(a, b int) bool { return F[sorted[a]][m] < F[sorted[b]][m] })
+        low, high := F[sorted[0]][m], F[sorted[len(sorted)-1]][m]
+        distance[sorted[0]] = math.Inf(1)
+        distance[sorted[len(sorted)-1]] = math.Inf(1)
+        if high == low {
+            continue
+        }
+        for k := 1; k + 1 < len(sorted); k++ {
+            distance[sorted[k]] += (F[sorted[k+1]][m] - F[sorted[k-1]][m])/(high - low)
+        }
+    }
+    return distance
+}
+
+// NSGA2 (Non-dominated sorting genetic algorithm II)
+// Evolves a population of np points in the box [lower, upper] towards the Pareto front of the vector valued f, all
+// objectives are minimized. Parents are chosen by binary tournaments on front rank and crowding distance, offspring
+// are built by simulated binary crossover with probability 0.9 and polynomial mutation of each component with
+// probability 1/n, and the best np of parents and offspring survive. The offspring are generated from the seeded
+// random number generator before they are evaluated by up to workers goroutines, so the result does not depend on workers.
+// input:
+// the objectives to minimize (f), lower bounds (lower), upper bounds (upper), even population size of at least 4 (np), crossover distribution index (etac), mutation distribution index (etam), seed of the random number generator (seed), number of goroutines evaluating f (workers), generations (maxit)
+// output:
+// the distinct non-dominated points of the final population sorted by their objectives (X), their objective vectors (F), generations done (iter)
+func NSGA2(f func([]float64) []float64, lower []float64, upper []float64, np int, etac float64, etam float64, seed int64, workers int, maxit int) (X [][]float64, F [][]float64, iter int, err error) {
+    if err = checkBox(lower, upper); err != nil {
+        return nil, nil, 0, err
+    }
+    if np < 4 || np%2 != 0 {
+        return nil, nil, 0, errors.New("np must be even and at least 4")
+    }
+    if etac <= 0.0 || etam <= 0.0 {
+        return nil, nil, 0, errors.New("etac and etam must be greater than 0")
+    }
+    n := len(lower)
+    rng := rand.New(rand.NewSource(seed))
+    clip := func(v float64, d int) float64 {
+        return math.Min(math.Max(v, lower[d]), upper[d])
+    }
+    pop := make([][]float64, np)
+    for i := range pop {
+        pop[i] = make([]float64, n)
+        for d := range pop[i] {
+            pop[i][d] = lower[d] + rng.Float64()*(upper[d] - lower[d])
+        }
+    }
+    fpop := evaluateObjectives(f, pop, workers)
+    m := len(fpop[0])
+    for _, v := range fpop {
+        if len(v) != m || m == 0 {
+            return nil, nil, 0, errors.New("f must return nonempty vectors of the same length")
+        }
+    }
+    fronts, rank := nondominatedSort(fpop)
+    distance := map[int]float64{}
+    for _, front := range fronts {
+        for i, v := range crowding(fpop, front) {
+            distance[i] = v
+        }
+    }
+    better := func(a, b int) bool {
+        return rank[a] < rank[b] || rank[a] == rank[b] && distance[a] > distance[b]
+    }
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        children := make([][]float64, 0, np)
+        for len(children) < np {
+            var parents [2][]float64
+            for k := range parents {
+                a, b := rng.Intn(np), rng.Intn(np)
+                if better(b, a) {
+                    a = b
+                }
+                parents[k] = pop[a]
+            }
+            c1 := append([]float64(nil), parents[0]...)
+            c2 := append([]float64(nil), parents[1]...)
+            if rng.Float64() < 0.9 {
+                for d := 0; d < n; d++ {
+                    if rng.Float64() >= 0.5 || math.Abs(c1[d] - c2[d]) < 1e-14 {
+                        continue
+                    }
+                    u := rng.Float64()
+                    beta := math.Pow(2.0*u, 1.0/(etac + 1.0))
+                    if u > 0.5 {
+                        beta = math.Pow(1.0/(2.0*(1.0 - u)), 1.0/(etac + 1.0))
+                    }
+                    p1, p2 := c1[d], c2[d]
+                    c1[d] = clip(0.5*((1.0 + beta)*p1 + (1.0 - beta)*p2), d)
+                    c2[d] = clip(0.5*((1.0 - beta)*p1 + (1.0 + beta)*p2), d)
+                }
+            }
+            for _, c := range [][]float64{c1, c2} {
+                for d := range c {
+                    if rng.Float64() >= 1.0/float64(n) {
+                        continue
+                    }
+                    u := rng.Float64()
+                    delta := math.Pow(2.0*u, 1.0/(etam + 1.0)) - 1.0
+                    if u >= 0.5 {
+                        delta = 1.0 - math.Pow(2.0*(1.0 - u), 1.0/(etam + 1.0))
+                    }
+                    c[d] = clip(c[d] + delta*(upper[d] - lower[d]), d)
+                }
+                children = append(children, c)
+            }
+        }
+        fchildren := evaluateObjectives(f, children, workers)
+        for _, v := range fchildren {
+            if len(v) != m {
+                return nil, nil, 0, errors.New("f must return nonempty vectors of the same length")
+            }
+        }
+        all := append(append([][]float64(nil), pop...), children...)
+        fall := append(append([][]float64(nil), fpop...), fchildren...)
+        fronts, _ = nondominatedSort(fall)
+        // Whole fronts survive while they fit, the last one is cut by decreasing crowding distance
+        var survivors []int
+        var sdistance []float64
+        var srank []int
+        for r, front := range fronts {
+            cd := crowding(fall, front)
+            sorted := append([]int(nil), front...)
+            if len(survivors) + len(front) > np {
+                sort.SliceStable(sorted, func(a, b int) bool { return cd[sorted[a]] > cd[sorted[b]] })
+                sorted = sorted[:np - len(survivors)]
+            }
+            for _, i := range sorted {
+                survivors = append(survivors, i)
+                sdistance = append(sdistance, cd[i])
+                srank = append(srank, r)
+            }
+            if len(survivors) == np {
+                break
+            }
+        }
+        for k, i := range survivors {
+            pop[k], fpop[k] = all[i], fall[i]
+            rank[k], distance[k] = srank[k], sdistance[k]
+        }
+    }
+    front := ParetoFront(fpop)
+    less := func(a, b []float64) bool {
+        for k := range a {
+            if a[k] != b[k] {
+                return a[k] < b[k]
+            }
+        }
+        return false
+    }
+    // Lexicographic order puts copies of a point next to each other, only the first is kept
+    sort.SliceStable(front, func(a, b int) bool { return less(fpop[front[a]], fpop[front[b]]) })
+    for _, i := range front {
+        if len(F) > 0 && !less(F[len(F)-1], fpop[i]) {
+            continue
+        }
+        X = append(X, pop[i])
+        F = append(F, fpop[i])
+    }
+    return X, F, iter, nil
+}
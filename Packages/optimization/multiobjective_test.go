@@ -0,0 +1,106 @@
+package optimization
+
+import (
+    "testing"
+    "fmt"
+    "math"
+    "strings"
+)
+
+// TestNSGA2 calls optimization.NSGA2 with ZDT1 in 10 dimensions, checking that the front is close to the true
+// front, spread over it and independent of the number of workers.
+func TestNSGA2(t *testing.T) {
+    zdt1 := func(x []float64) []float64 { // the Pareto front is f1 = 1 - sqrt(f0) for x1 = ... = 0
+        g := 0.0
+        for _, v := range x[1:] {
+            g += v
+        }
+        g = 1.0 + 9.0*g/float64(len(x) - 1)
+        return []float64{x[0], g*(1.0 - math.Sqrt(x[0]/g))}
+    }
+    lower := make([]float64, 10)
+    upper := make([]float64, 10)
+    for i := range upper {
+        upper[i] = 1.0
+    }
+    X, F, iter, err := NSGA2(zdt1, lower, upper, 100, 20.0, 20.0, 1, 4, 250)
+    if err != nil || iter != 250 || len(F) < 50 || len(X) != len(F) {
+        t.Fatalf(`NSGA2(zdt1, lower, upper, 100, 20, 20, 1, 4, 250) = %d points, %d, %v, want at least 50 points, 250, nil`, len(F), iter, err)
+    }
+    for k, v := range F {
+        g := 1.0 // the front has g = 1
+        for _, x := range X[k][1:] {
+            g += x
+        }
+        if math.Abs(v[1] - (1.0 - math.Sqrt(v[0]))) > 0.05 || k > 0 && v[0] < F[k-1][0] || g > 1.05 {
+            t.Fatalf(`NSGA2(zdt1, ...) F[%d] = %v, want near f1 = 1 - sqrt(f0) sorted by f0`, k, v)
+        }
+    }
+    hv, err := Hypervolume(F, []float64{1.1, 1.1})
+    hvwant := 0.1 + 2.0/3.0 + 0.11
+    if err != nil || hv < hvwant - 0.01 || hv > hvwant {
+        t.Fatalf(`Hypervolume(F, [1.1, 1.1]) = %f, %v, want %f`, hv, err, hvwant)
+    }
+    _, serial, _, _ := NSGA2(zdt1, lower, upper, 100, 20.0, 20.0, 1, 1, 250)
+    if fmt.Sprintf("%v", serial) != fmt.Sprintf("%v", F) {
+        t.Fatalf(`NSGA2(zdt1, ...) with 1 worker differs from 4 workers`)
+    }
+}
+
+// TestNSGA2Input calls optimization.NSGA2 with an odd population size, checking for an error.
+func TestNSGA2Input(t *testing.T) {
+    zdt1 := func(x []float64) []float64 { // the Pareto front is f1 = 1 - sqrt(f0) for x1 = ... = 0
+        g := 0.0
+        for _, v := range x[1:] {
+            g += v
+        }
+        g = 1.0 + 9.0*g/float64(len(x) - 1)
+        return []float64{x[0], g*(1.0 - math.Sqrt(x[0]/g))}
+    }
+    lower := []float64{0.0, 0.0, 0.0}
+    upper := []float64{1.0, 1.0, 1.0}
+    X, F, iter, err := NSGA2(zdt1, lower, upper, 11, 20.0, 20.0, 1, 1, 10)
+    if X != nil || F != nil || iter != 0 || err == nil {
+        t.Fatalf(`NSGA2(zdt1, lower, upper, 11, 20, 20, 1, 1, 10) = %v, %v, %d, %v, want nil, nil, 0, error`, X, F, iter, err)
+    }
+}
+
+// TestParetoFront calls optimization.ParetoFront with dominated and non-dominated vectors, checking for the
+// indices of the non-dominated ones.
+func TestParetoFront(t *testing.T) {
+    F := [][]float64{{1.0, 5.0}, {2.0, 2.0}, {3.0, 3.0}, {5.0, 1.0}, {2.0, 4.0}}
+    front := ParetoFront(F)
+    if fmt.Sprintf("%v", front) != "[0 1 3]" {
+        t.Fatalf(`ParetoFront(%v) = %v, want [0 1 3]`, F, front)
+    }
+}
+
+// TestHypervolume calls optimization.Hypervolume with fronts in two and three objectives, checking against the
+// volumes of the unions of boxes.
+func TestHypervolume(t *testing.T) {
+    cases := []struct {
+        F    [][]float64
+        ref  []float64
+        want float64
+    }{
+        {[][]float64{{1.0, 3.0}, {2.0, 2.0}, {3.0, 1.0}, {3.0, 3.0}, {5.0, 0.0}}, []float64{4.0, 4.0}, 6.0},
+        {[][]float64{{0.0, 0.0, 0.0}}, []float64{1.0, 2.0, 3.0}, 6.0},
+        {[][]float64{{0.0, 1.0, 1.0}, {1.0, 0.0, 1.0}, {1.0, 1.0, 0.0}}, []float64{2.0, 2.0, 2.0}, 4.0},
+    }
+    for _, c := range cases {
+        hv, err := Hypervolume(c.F, c.ref)
+        if err != nil || math.Abs(hv - c.want) > 1e-12 {
+            t.Fatalf(`Hypervolume(%v, %v) = %f, %v, want %f, nil`, c.F, c.ref, hv, err, c.want)
+        }
+    }
+}
+
+// TestWriteFrontCSV calls optimization.WriteFrontCSV with two points, checking for the header and the records.
+func TestWriteFrontCSV(t *testing.T) {
+    var sb strings.Builder
+    err := WriteFrontCSV(&sb, [][]float64{{0.5, 1.0}, {0.25, 0.0}}, [][]float64{{1.5, -2.0}, {0.1, 3.0}})
+    want := "x0,x1,f0,f1\n0.5,1,1.5,-2\n0.25,0,0.1,3\n"
+    if err != nil || sb.String() != want {
+        t.Fatalf(`WriteFrontCSV(w, X, F) wrote %q, %v, want %q, nil`, sb.String(), err, want)
+    }
+}
@@ -0,0 +1,251 @@
+package optimization
+
+import (
+    "errors"
+    "math"
+    "math/rand"
+    "sort"
+    "sync"
+)
+
+// LocalMinimizer runs a local minimization of f from x0, the multivariate minimizers fit with a closure fixing the
+// remaining arguments
+// input:
+// the function to find the minimum for (f), initial guess (x0)
+// output:
+// the estimated x (x), function value (fx), error estimate (ea), iterations done (iter)
+type LocalMinimizer func(f func([]float64) float64, x0 []float64) (x []float64, fx float64, ea float64, iter int, err error)
+
+// UnivariateLocal (Adapter for interval based one dimensional minimizers)
+// input:
+// a minimizer of f on [xl, xu] such as Goldmin, Brentmin or Parabolic with the remaining arguments fixed (minimize), half width of the interval around the starting point (h)
+// output:
+// a LocalMinimizer for functions of one variable that minimizes on [x0 - h, x0 + h]
+func UnivariateLocal(minimize func(f func(float64) float64, xl float64, xu float64) (float64, float64, float64, int, error), h float64) LocalMinimizer {
+    return func(f func([]float64) float64, x0 []float64) (x []float64, fx float64, ea float64, iter int, err error) {
+        if len(x0) != 1 {
+            return nil, 0.0, 0.0, 0, errors.New("x0 must have length 1")
+        }
+        g := func(t float64) float64 {
+            return f([]float64{t})
+        }
+        xm, fx, ea, iter, err := minimize(g, x0[0] - h, x0[0] + h)
+        if err != nil {
+            return nil, 0.0, 0.0, 0, err
+        }
+        return []float64{xm}, fx, ea, iter, nil
+    }
+}
+
+// Sampling selects how the starting points of MultiStart are placed in the box
+type Sampling int
+
+const (
+    // UniformSampling draws independent uniform points
+    UniformSampling Sampling = iota
+    // LatinHypercube places exactly one point in each of the np slices of every coordinate
+    LatinHypercube
+    // SobolSampling uses the low discrepancy Sobol sequence with the direction numbers of Joe and Kuo, at most 21 dimensions
+    SobolSampling
+)
+
+// sobolParameters holds the degree s, the coefficients a and the initial direction numbers m of the primitive
+// polynomials for the Sobol dimensions 2 to 21 (Joe and Kuo, new-joe-kuo-6.21201)
+var sobolParameters = []struct {
+    s int
+    a uint32
+    m []uint32
+}{
+    {1, 0, []uint32{1}},
+    {2, 1, []uint32{1, 3}},
+    {3, 1, []uint32{1, 3, 1}},
+    {3, 2, []uint32{1, 1, 1}},
+    {4, 1, []uint32{1, 1, 3, 3}},
+    {4, 4, []uint32{1, 3, 5, 13}},
+    {5, 2, []uint32{1, 1, 5, 5, 17}},
+    {5, 4, []uint32{1, 1, 5, 5, 5}},
+    {5, 7, []uint32{1, 1, 7, 11, 19}},
+    {5, 11, []uint32{1, 1, 5, 1, 1}},
+    {5, 13, []uint32{1, 1, 1, 3, 11}},
+    {5, 14, []uint32{1, 3, 5, 5, 31}},
+    {6, 1, []uint32{1, 3, 3, 9, 7, 49}},
+    {6, 13, []uint32{1, 1, 1, 15, 21, 21}},
+    {6, 16, []uint32{1, 3, 1, 13, 27, 49}},
+    {6, 19, []uint32{1, 1, 1, 15, 7, 5}},
+    {6, 22, []uint32{1, 3, 1, 15, 13, 25}},
+    {6, 25, []uint32{1, 1, 5, 5, 19, 61}},
+    {7, 1, []uint32{1, 3, 7, 11, 23, 15, 103}},
+    {7, 4, []uint32{1, 3, 7, 13, 13, 15, 69}},
+}
+
+// sobol returns the first np points of the n dimensional Sobol sequence in [0, 1)^n, skipping the origin
+func sobol(n int, np int) ([][]float64, error) {
+    if n > len(sobolParameters) + 1 {
+        return nil, errors.New("Sobol sampling supports at most 21 dimensions")
+    }
+    const bits = 32
+    V := make([][]uint32, n)
+    for d := 0; d < n; d++ {
+        V[d] = make([]uint32, bits + 1)
+        if d == 0 {
+            for k := 1; k <= bits; k++ {
+                V[d][k] = 1 << uint(bits - k)
+            }
+            continue
+        }
+        p := sobolParameters[d-1]
+        for k := 1; k <= bits; k++ {
+            if k <= p.s {
+                V[d][k] = p.m[k-1] << uint(bits - k)
+                continue
+            }
+            V[d][k] = V[d][k-p.s] ^ (V[d][k-p.s] >> uint(p.s))
+            for j := 1; j < p.s; j++ {
+                if (p.a >> uint(p.s - 1 - j))&1 == 1 {
+                    V[d][k] ^= V[d][k-j]
+                }
+            }
+        }
+    }
+    points := make([][]float64, np)
+    X := make([]uint32, n)
+    for i := 0; i < np; i++ {
+        c := 1 // position of the rightmost zero bit of i, Gray code order
+        for v := i; v&1 == 1; v >>= 1 {
+            c++
+        }
+        points[i] = make([]float64, n)
+        for d := range X {
+            X[d] ^= V[d][c]
+            points[i][d] = float64(X[d])/4294967296.0
+        }
+    }
+    return points, nil
+}
+
+// samples returns np starting points in the box [lower, upper]
+func samples(lower []float64, upper []float64, np int, sampling Sampling, rng *rand.Rand) ([][]float64, error) {
+    n := len(lower)
+    var unit [][]float64
+    switch sampling {
+    case SobolSampling:
+        var err error
+        unit, err = sobol(n, np)
+        if err != nil {
+            return nil, err
+        }
+    case LatinHypercube:
+        unit = make([][]float64, np)
+        for i := range unit {
+            unit[i] = make([]float64, n)
+        }
+        for d := 0; d < n; d++ {
+            for i, slice := range rng.Perm(np) {
+                unit[i][d] = (float64(slice) + rng.Float64())/float64(np)
+            }
+        }
+    default:
+        unit = make([][]float64, np)
+        for i := range unit {
+            unit[i] = make([]float64, n)
+            for d := range unit[i] {
+                unit[i][d] = rng.Float64()
+            }
+        }
+    }
+    for _, p := range unit {
+        for d := range p {
+            p[d] = lower[d] + p[d]*(upper[d] - lower[d])
+        }
+    }
+    return unit, nil
+}
+
+// LocalMinimum is a distinct local minimum found by MultiStart
+type LocalMinimum struct {
+    X     []float64 // location of the minimum
+    Fx    float64   // function value
+    Count int       // number of starting points whose local minimization ended here
+}
+
+// MultiStart (Multistart global minimization)
+// Runs the local minimizer from np starting points sampled in the box [lower, upper] using up to workers goroutines and
+// clusters the results, two results belong to the same minimum when they differ by at most radius times the box width
+// in every coordinate. Runs that fail are ignored.
+// input:
+// the function to find the minimum for (f), local minimizer (local), lower bounds (lower), upper bounds (upper), number of starting points (np), sampling of the starting points (sampling), cluster radius relative to the box width (radius), seed of the random number generator (seed), number of goroutines running the local minimizer (workers)
+// output:
+// the best x (x), function value (fx), the distinct local minima sorted by function value (minima)
+func MultiStart(f func([]float64) float64, local LocalMinimizer, lower []float64, upper []float64, np int, sampling Sampling, radius float64, seed int64, workers int) (x []float64, fx float64, minima []LocalMinimum, err error) {
+    if err = checkBox(lower, upper); err != nil {
+        return nil, 0.0, nil, err
+    }
+    if local == nil {
+        return nil, 0.0, nil, errors.New("local must not be nil")
+    }
+    if np < 1 {
+        return nil, 0.0, nil, errors.New("np must be at least 1")
+    }
+    if sampling < UniformSampling || sampling > SobolSampling {
+        return nil, 0.0, nil, errors.New("Unknown sampling")
+    }
+    if radius < 0.0 {
+        return nil, 0.0, nil, errors.New("radius must be greater than or equal to 0")
+    }
+    if workers < 1 {
+        workers = 1
+    }
+    starts, err := samples(lower, upper, np, sampling, rand.New(rand.NewSource(seed)))
+    if err != nil {
+        return nil, 0.0, nil, err
+    }
+    results := make([]*LocalMinimum, np)
+    next := make(chan int)
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range next {
+                xl, fl, _, _, lerr := local(f, starts[i])
+                if lerr == nil && !math.IsNaN(fl) {
+                    results[i] = &LocalMinimum{xl, fl, 1}
+                }
+            }
+        }()
+    }
+    for i := range starts {
+        next <- i
+    }
+    close(next)
+    wg.Wait()
+    var found []*LocalMinimum
+    for _, r := range results {
+        if r != nil {
+            found = append(found, r)
+        }
+    }
+    if len(found) == 0 {
+        return nil, 0.0, nil, errors.New("All local minimizations failed")
+    }
+    sort.SliceStable(found, func(a, b int) bool { return found[a].Fx < found[b].Fx })
+    // Greedy clustering, every result joins the first (best) representative close enough to it
+    for _, r := range found {
+        joined := false
+        for k := range minima {
+            near := true
+            for d := range r.X {
+                near = near && math.Abs(r.X[d] - minima[k].X[d]) <= radius*(upper[d] - lower[d])
+            }
+            if near {
+                minima[k].Count++
+                joined = true
+                break
+            }
+        }
+        if !joined {
+            minima = append(minima, *r)
+        }
+    }
+    return minima[0].X, minima[0].Fx, minima, nil
+}
@@ -0,0 +1,110 @@
+package optimization
+
+import (
+    "testing"
+    "fmt"
+    "math"
+    "math/rand"
+)
+
+// TestSobol calls optimization.sobol for the first points in three dimensions, checking them against the
+// published sequence.
+func TestSobol(t *testing.T) {
+    want := [][]float64{{0.5, 0.5, 0.5}, {0.75, 0.25, 0.25}, {0.25, 0.75, 0.75}, {0.375, 0.375, 0.625}, {0.875, 0.875, 0.125}}
+    points, err := sobol(3, len(want))
+    msg := fmt.Sprintf("%v", points)
+    if err != nil || msg != fmt.Sprintf("%v", want) {
+        t.Fatalf(`sobol(3, 5) = %q, %v, want match for %#v, nil`, msg, err, fmt.Sprintf("%v", want))
+    }
+    if _, err := sobol(22, 5); err == nil {
+        t.Fatalf(`sobol(22, 5) = nil error, want error`)
+    }
+}
+
+// TestLatinHypercube calls optimization.samples with Latin hypercube sampling, checking that every slice of every
+// coordinate holds exactly one point.
+func TestLatinHypercube(t *testing.T) {
+    np := 10
+    lower, upper := []float64{-1.0, 0.0, 5.0}, []float64{1.0, 10.0, 6.0}
+    points, err := samples(lower, upper, np, LatinHypercube, rand.New(rand.NewSource(3)))
+    if err != nil {
+        t.Fatalf(`samples(lower, upper, 10, LatinHypercube, rng) = %v, want nil`, err)
+    }
+    for d := range lower {
+        seen := make([]bool, np)
+        for _, p := range points {
+            k := int((p[d] - lower[d])/(upper[d] - lower[d])*float64(np))
+            if k < 0 || k >= np || seen[k] {
+                t.Fatalf(`samples(lower, upper, 10, LatinHypercube, rng) coordinate %d = %v, want one point per slice`, d, points)
+            }
+            seen[k] = true
+        }
+    }
+}
+
+// TestMultiStart calls optimization.MultiStart with Himmelblau's function, BFGS and every sampling, checking that
+// the four minima are found independently of the number of workers.
+func TestMultiStart(t *testing.T) {
+    bfgsLocal := func(f func([]float64) float64, x0 []float64) ([]float64, float64, float64, int, error) {
+        return BFGS(f, nil, x0, nil, 1e-8, 200)
+    }
+    himmelblau := func(x []float64) float64 { // four global minima with value 0
+        a := x[0]*x[0] + x[1] - 11.0
+        b := x[0] + x[1]*x[1] - 7.0
+        return a*a + b*b
+    }
+    lower, upper := []float64{-5.0, -5.0}, []float64{5.0, 5.0}
+    for _, sampling := range []Sampling{UniformSampling, LatinHypercube, SobolSampling} {
+        x, fx, minima, err := MultiStart(himmelblau, bfgsLocal, lower, upper, 40, sampling, 1e-3, 1, 4)
+        if err != nil || fx > 1e-10 || len(minima) != 4 {
+            t.Fatalf(`MultiStart(himmelblau, bfgs, lower, upper, 40, %d, 1e-3, 1, 4) = %v, %g, %v, %v, want 4 minima with value 0`, sampling, x, fx, minima, err)
+        }
+        count := 0
+        for k, m := range minima {
+            count += m.Count
+            if k > 0 && m.Fx < minima[k-1].Fx {
+                t.Fatalf(`MultiStart(himmelblau, ...) minima = %v, want sorted by value`, minima)
+            }
+        }
+        _, _, serial, _ := MultiStart(himmelblau, bfgsLocal, lower, upper, 40, sampling, 1e-3, 1, 1)
+        if count != 40 || fmt.Sprintf("%v", serial) != fmt.Sprintf("%v", minima) {
+            t.Fatalf(`MultiStart(himmelblau, ...) = %v with 4 workers and %v with 1, want 40 runs and equal results`, minima, serial)
+        }
+    }
+}
+
+// TestMultiStartUnivariate calls optimization.MultiStart with a one dimensional function and Goldmin through
+// UnivariateLocal, checking for the global minimum and the neighbouring local minima.
+func TestMultiStartUnivariate(t *testing.T) {
+    f := func(x []float64) float64 { return x[0]*x[0]/10.0 - 2.0*math.Sin(x[0]) }
+    local := UnivariateLocal(func(g func(float64) float64, xl float64, xu float64) (float64, float64, float64, int, error) {
+        return Goldmin(g, xl, xu, 1e-8, 200)
+    }, 0.5)
+    x, fx, minima, err := MultiStart(f, local, []float64{-10.0}, []float64{10.0}, 50, SobolSampling, 1e-4, 1, 2)
+    if err != nil || math.Abs(x[0] - 1.42755) > 1e-4 || len(minima) < 2 {
+        t.Fatalf(`MultiStart(f, goldmin, [-10], [10], 50, SobolSampling, 1e-4, 1, 2) = %v, %g, %v, %v, want 1.42755`, x, fx, minima, err)
+    }
+}
+
+// TestMultiStartInput calls optimization.MultiStart without a local minimizer and with an unknown sampling,
+// checking for errors.
+func TestMultiStartInput(t *testing.T) {
+    bfgsLocal := func(f func([]float64) float64, x0 []float64) ([]float64, float64, float64, int, error) {
+        return BFGS(f, nil, x0, nil, 1e-8, 200)
+    }
+    himmelblau := func(x []float64) float64 { // four global minima with value 0
+        a := x[0]*x[0] + x[1] - 11.0
+        b := x[0] + x[1]*x[1] - 7.0
+        return a*a + b*b
+    }
+    calls := []struct {
+        local    LocalMinimizer
+        sampling Sampling
+    }{{nil, UniformSampling}, {bfgsLocal, Sampling(3)}}
+    for _, c := range calls {
+        x, fx, minima, err := MultiStart(himmelblau, c.local, []float64{-5.0, -5.0}, []float64{5.0, 5.0}, 10, c.sampling, 1e-3, 1, 1)
+        if x != nil || fx != 0.0 || minima != nil || err == nil {
+            t.Fatalf(`MultiStart(himmelblau, local, lower, upper, 10, %d, 1e-3, 1, 1) = %v, %g, %v, %v, want nil, 0, nil, error`, c.sampling, x, fx, minima, err)
+        }
+    }
+}
@@ -0,0 +1,145 @@
+package optimization
+
+import (
+    "errors"
+    "math"
+    "sort"
+)
+
+// simplex holds the vertices of a Nelder-Mead simplex and their function values, sorted from best to worst
+type simplex struct {
+    x  [][]float64
+    fx []float64
+}
+
+func (s *simplex) Len() int { return len(s.fx) }
+func (s *simplex) Less(i, j int) bool { return s.fx[i] < s.fx[j] }
+func (s *simplex) Swap(i, j int) {
+    s.x[i], s.x[j] = s.x[j], s.x[i]
+    s.fx[i], s.fx[j] = s.fx[j], s.fx[i]
+}
+
+// newSimplex builds the simplex x0, x0 + step*max(|x0_i|, 1)*e_i for i = 1..n
+func newSimplex(f func([]float64) float64, x0 []float64, step float64) *simplex {
+    n := len(x0)
+    s := &simplex{make([][]float64, n + 1), make([]float64, n + 1)}
+    s.x[0] = append([]float64(nil), x0...)
+    s.fx[0] = f(s.x[0])
+    for i := 0; i < n; i++ {
+        v := append([]float64(nil), x0...)
+        v[i] += step*math.Max(math.Abs(x0[i]), 1.0)
+        s.x[i+1] = v
+        s.fx[i+1] = f(v)
+    }
+    sort.Sort(s)
+    return s
+}
+
+// spread returns the relative simplex size and the relative spread of the function values
+func (s *simplex) spread() (size float64, fspread float64) {
+    best := s.x[0]
+    for _, v := range s.x[1:] {
+        for i := range v {
+            size = math.Max(size, math.Abs(v[i] - best[i]))
+        }
+    }
+    size /= math.Max(norminf(best), 1.0)
+    fspread = (s.fx[len(s.fx)-1] - s.fx[0])/math.Max(math.Abs(s.fx[0]), 1.0)
+    return size, fspread
+}
+
+// NelderMead (Nelder-Mead downhill simplex with adaptive parameters)
+// The reflection, expansion, contraction and shrink parameters are adapted to the dimension (Gao and Han),
+// after convergence the simplex is rebuilt around the best point up to restarts times
+// input:
+// the function to find the minimum for (f), initial guess (x0), relative size of the initial simplex (step), number of restarts (restarts), error deviation for both simplex size and function spread (es), maximum iterations (iter)
+// output:
+// the estimated x (x), function value (fx), error estimate (ea), iterations done (iter)
+func NelderMead(f func([]float64) float64, x0 []float64, step float64, restarts int, es float64, maxit int) (x []float64, fx float64, ea float64, iter int, err error) {
+    if es < 0.0 {
+        return nil, 0.0, 0.0, 0, errors.New("es must be greater than 0")
+    }
+    if len(x0) == 0 {
+        return nil, 0.0, 0.0, 0, errors.New("x0 must not be empty")
+    }
+    if step <= 0.0 {
+        return nil, 0.0, 0.0, 0, errors.New("step must be greater than 0")
+    }
+    n := len(x0)
+    dim := float64(n)
+    alpha := 1.0
+    beta := 1.0 + 2.0/dim
+    gamma := 0.75 - 1.0/(2.0*dim)
+    delta := 1.0 - 1.0/dim
+    if n == 1 {
+        gamma, delta = 0.5, 0.5
+    }
+    s := newSimplex(f, x0, step)
+    centroid := make([]float64, n)
+    point := func(t float64) []float64 { // centroid + t*(centroid - worst)
+        p := make([]float64, n)
+        for i := range p {
+            p[i] = centroid[i] + t*(centroid[i] - s.x[n][i])
+        }
+        return p
+    }
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        size, fspread := s.spread()
+        ea = math.Max(size, fspread)
+        if ea <= es {
+            if restarts <= 0 {
+                break
+            }
+            restarts--
+            s = newSimplex(f, s.x[0], step)
+            continue
+        }
+        for i := range centroid {
+            centroid[i] = 0.0
+            for _, v := range s.x[:n] {
+                centroid[i] += v[i]
+            }
+            centroid[i] /= dim
+        }
+        xr := point(alpha)
+        fr := f(xr)
+        switch {
+        case fr < s.fx[0]: // Expansion
+            xe := point(alpha*beta)
+            fe := f(xe)
+            if fe < fr {
+                s.x[n], s.fx[n] = xe, fe
+            } else {
+                s.x[n], s.fx[n] = xr, fr
+            }
+        case fr < s.fx[n-1]: // Reflection
+            s.x[n], s.fx[n] = xr, fr
+        default: // Outside or inside contraction, shrink if both fail
+            var xc []float64
+            var fc float64
+            accept := false
+            if fr < s.fx[n] {
+                xc = point(alpha*gamma)
+                fc = f(xc)
+                accept = fc <= fr
+            } else {
+                xc = point(-gamma)
+                fc = f(xc)
+                accept = fc < s.fx[n]
+            }
+            if accept {
+                s.x[n], s.fx[n] = xc, fc
+            } else {
+                for k := 1; k <= n; k++ {
+                    for i := range s.x[k] {
+                        s.x[k][i] = s.x[0][i] + delta*(s.x[k][i] - s.x[0][i])
+                    }
+                    s.fx[k] = f(s.x[k])
+                }
+            }
+        }
+        sort.Stable(s)
+    }
+    return s.x[0], s.fx[0], ea, iter, nil
+}
@@ -0,0 +1,63 @@
+package optimization
+
+import (
+    "testing"
+    "fmt"
+    "math"
+)
+
+// TestNelderMead calls optimization.NelderMead with the Rosenbrock function, an initial guess, simplex step,
+// restarts, error limit and max iterations, checking for a valid return value.
+func TestNelderMead(t *testing.T) {
+    x0 := []float64{-1.2, 1.0}
+    step := 0.1
+    restarts := 1
+    es := 1e-10
+    maxit := 2000
+    f := func(x []float64) float64 {
+        return (1.0 - x[0])*(1.0 - x[0]) + 100.0*(x[1] - x[0]*x[0])*(x[1] - x[0]*x[0])
+    }
+    xwant := []float64{1.0, 1.0}
+    fxwant := 0.0
+    x, fx, ea, iter, err := NelderMead(f, x0, step, restarts, es, maxit)
+    msg := fmt.Sprintf("%v, %g, %g, %d", x, fx, ea, iter)
+    want := fmt.Sprintf("%v, %g, %g, %d < %d", xwant, fxwant, es, iter, maxit)
+    if err != nil || math.Abs(x[0] - xwant[0]) > 1e-4 || math.Abs(x[1] - xwant[1]) > 1e-4 || fx > 1e-8 || ea > es || iter >= maxit {
+        t.Fatalf(`NelderMead(rosenbrock, [-1.2, 1], 0.1, 1, 1e-10, 2000) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestNelderMeadHighDimension calls optimization.NelderMead with an ill-scaled quadratic in 10 dimensions,
+// checking that the adaptive parameters reach the minimum.
+func TestNelderMeadHighDimension(t *testing.T) {
+    n := 10
+    x0 := make([]float64, n)
+    for i := range x0 {
+        x0[i] = 1.0
+    }
+    f := func(x []float64) float64 {
+        s := 0.0
+        for i, v := range x {
+            s += float64(i + 1)*(v - 0.5)*(v - 0.5)
+        }
+        return s
+    }
+    x, fx, ea, iter, err := NelderMead(f, x0, 0.5, 2, 1e-12, 20000)
+    for i := range x {
+        if err != nil || math.Abs(x[i] - 0.5) > 1e-4 || iter >= 20000 {
+            t.Fatalf(`NelderMead(f, ones(10), 0.5, 2, 1e-12, 20000) = %v, %g, %g, %d, %v, want all 0.5`, x, fx, ea, iter, err)
+        }
+    }
+}
+
+// TestNelderMeadEmpty calls optimization.NelderMead with an empty initial guess,
+// checking for an error.
+func TestNelderMeadEmpty(t *testing.T) {
+    f := func(x []float64) float64 {
+        return 0.0
+    }
+    x, fx, ea, iter, err := NelderMead(f, []float64{}, 0.1, 0, 1e-6, 10)
+    if x != nil || fx != 0.0 || ea != 0.0 || iter != 0 || err == nil {
+        t.Fatalf(`NelderMead(f, [], 0.1, 0, 1e-6, 10) = %v, %f, %f, %d, %v, want nil, 0, 0, 0, error`, x, fx, ea, iter, err)
+    }
+}
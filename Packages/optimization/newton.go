@@ -0,0 +1,183 @@
+package optimization
+
+import (
+    "errors"
+    "math"
+    "example.com/numdiff"
+)
+
+// fdHessian returns a finite difference approximation of the Hessian, the symmetrized numdiff Jacobian of the
+// gradient when it is supplied and the numdiff Hessian of f otherwise
+func fdHessian(f func([]float64) float64, grad func([]float64) []float64) func([]float64) [][]float64 {
+    return func(x []float64) [][]float64 {
+        if grad == nil {
+            H, _ := numdiff.Hessian(f, x) // the minimizers reject an empty x0, the only failure
+            return H
+        }
+        H, _ := numdiff.Jacobian(grad, x)
+        for i := range H { // symmetrize
+            for j := 0; j < i; j++ {
+                H[i][j] = 0.5*(H[i][j] + H[j][i])
+                H[j][i] = H[i][j]
+            }
+        }
+        return H
+    }
+}
+
+// Newton (Line search Newton method with Hessian modification)
+// When the Hessian is not positive definite a multiple of the identity is added until the Cholesky factorization succeeds,
+// so the step is always a descent direction
+// input:
+// the function to find the minimum for (f), the gradient of f (grad, nil uses central differences), the Hessian of f (hess, nil uses finite differences), initial guess (x0), line search (ls, nil uses the default), gradient norm tolerance (es), maximum iterations (iter)
+// output:
+// the estimated x (x), function value (fx), largest absolute gradient entry (ea), iterations done (iter)
+func Newton(f func([]float64) float64, grad func([]float64) []float64, hess func([]float64) [][]float64, x0 []float64, ls LineSearch, es float64, maxit int) (x []float64, fx float64, ea float64, iter int, err error) {
+    if hess == nil {
+        hess = fdHessian(f, grad)
+    }
+    grad, ls, err = checkMultivariate(f, grad, x0, ls, es)
+    if err != nil {
+        return nil, 0.0, 0.0, 0, err
+    }
+    x = append([]float64(nil), x0...)
+    fx = f(x)
+    g := grad(x)
+    ea = norminf(g)
+    d := make([]float64, len(x))
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        if ea <= es {
+            break
+        }
+        L, _ := modifiedCholesky(hess(x))
+        alpha0 := 1.0
+        if L != nil {
+            d = choleskySolve(L, g)
+            for i := range d {
+                d[i] = -d[i]
+            }
+        }
+        slope := dot(g, d)
+        if L == nil || !(slope < 0.0) { // fall back to steepest descent
+            for i := range d {
+                d[i] = -g[i]
+            }
+            slope = dot(g, d)
+            alpha0 = firstStep(g)
+        }
+        phi, dphi, last := lineFunctions(f, grad, x, d)
+        alpha, fnew, lserr := ls(phi, dphi, fx, slope, alpha0)
+        if lserr != nil {
+            return x, fx, ea, iter, lserr
+        }
+        x, fx, g = axpy(x, alpha, d), fnew, last(alpha)
+        ea = norminf(g)
+    }
+    return x, fx, ea, iter, nil
+}
+
+// steihaug approximately minimizes g'p + p'Bp/2 subject to |p| <= delta with the truncated conjugate gradient method
+// of Steihaug, stopping on negative curvature or at the trust region boundary
+func steihaug(B [][]float64, g []float64, delta float64) []float64 {
+    n := len(g)
+    z := make([]float64, n)
+    r := append([]float64(nil), g...)
+    d := make([]float64, n)
+    for i := range d {
+        d[i] = -r[i]
+    }
+    rr := dot(r, r)
+    gnorm := math.Sqrt(rr)
+    tol := math.Min(0.5, math.Sqrt(gnorm))*gnorm
+    if gnorm <= tol {
+        return z
+    }
+    // boundary returns z + tau*d with tau >= 0 such that |z + tau*d| = delta
+    boundary := func() []float64 {
+        a := dot(d, d)
+        b := 2.0*dot(z, d)
+        c := dot(z, z) - delta*delta
+        tau := (-b + math.Sqrt(math.Max(0.0, b*b - 4.0*a*c)))/(2.0*a)
+        return axpy(z, tau, d)
+    }
+    for j := 0; j < 2*n; j++ {
+        Bd := matvec(B, d)
+        dBd := dot(d, Bd)
+        if dBd <= 0.0 {
+            return boundary()
+        }
+        alpha := rr/dBd
+        znew := axpy(z, alpha, d)
+        if math.Sqrt(dot(znew, znew)) >= delta {
+            return boundary()
+        }
+        z = znew
+        for i := range r {
+            r[i] += alpha*Bd[i]
+        }
+        rrnew := dot(r, r)
+        if math.Sqrt(rrnew) <= tol {
+            break
+        }
+        beta := rrnew/rr
+        rr = rrnew
+        for i := range d {
+            d[i] = -r[i] + beta*d[i]
+        }
+    }
+    return z
+}
+
+// TrustRegionNewton (Trust-region Newton method with a Steihaug-CG subproblem solver)
+// input:
+// the function to find the minimum for (f), the gradient of f (grad, nil uses central differences), the Hessian of f (hess, nil uses finite differences), initial guess (x0), initial trust region radius (delta0), gradient norm tolerance (es), maximum iterations (iter)
+// output:
+// the estimated x (x), function value (fx), largest absolute gradient entry (ea), iterations done (iter)
+func TrustRegionNewton(f func([]float64) float64, grad func([]float64) []float64, hess func([]float64) [][]float64, x0 []float64, delta0 float64, es float64, maxit int) (x []float64, fx float64, ea float64, iter int, err error) {
+    if hess == nil {
+        hess = fdHessian(f, grad)
+    }
+    grad, _, err = checkMultivariate(f, grad, x0, nil, es)
+    if err != nil {
+        return nil, 0.0, 0.0, 0, err
+    }
+    if delta0 <= 0.0 {
+        return nil, 0.0, 0.0, 0, errors.New("delta0 must be greater than 0")
+    }
+    const eta = 1e-4
+    deltamax := 1e3*math.Max(delta0, 1.0)
+    delta := delta0
+    x = append([]float64(nil), x0...)
+    fx = f(x)
+    g := grad(x)
+    B := hess(x)
+    ea = norminf(g)
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        if ea <= es {
+            break
+        }
+        p := steihaug(B, g, delta)
+        pred := -(dot(g, p) + 0.5*dot(p, matvec(B, p)))
+        xnew := axpy(x, 1.0, p)
+        fnew := f(xnew)
+        rho := (fx - fnew)/pred
+        pnorm := math.Sqrt(dot(p, p))
+        if rho < 0.25 || math.IsNaN(rho) {
+            delta = 0.25*pnorm
+        } else if rho > 0.75 && pnorm >= 0.99*delta {
+            delta = math.Min(2.0*delta, deltamax)
+        }
+        if rho > eta && pred > 0.0 {
+            x, fx = xnew, fnew
+            g = grad(x)
+            B = hess(x)
+            ea = norminf(g)
+        }
+        if delta <= 1e-15*math.Max(norminf(x), 1.0) { // the trust region collapsed
+            break
+        }
+    }
+    return x, fx, ea, iter, nil
+}
@@ -0,0 +1,116 @@
+package optimization
+
+import (
+    "testing"
+    "errors"
+    "fmt"
+    "math"
+)
+
+// TestNewton calls optimization.Newton with the Rosenbrock function, its gradient and Hessian, an initial guess,
+// the default line search, error limit and max iterations, checking for a valid return value.
+func TestNewton(t *testing.T) {
+    f := func(x []float64) float64 {
+        return (1.0 - x[0])*(1.0 - x[0]) + 100.0*(x[1] - x[0]*x[0])*(x[1] - x[0]*x[0])
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{-2.0*(1.0 - x[0]) - 400.0*x[0]*(x[1] - x[0]*x[0]), 200.0*(x[1] - x[0]*x[0])}
+    }
+    hess := func(x []float64) [][]float64 {
+        return [][]float64{{2.0 - 400.0*(x[1] - 3.0*x[0]*x[0]), -400.0*x[0]}, {-400.0*x[0], 200.0}}
+    }
+    x0 := []float64{-1.2, 1.0}
+    es := 1e-10
+    maxit := 100
+    xwant := []float64{1.0, 1.0}
+    fxwant := 0.0
+    x, fx, ea, iter, err := Newton(f, grad, hess, x0, nil, es, maxit)
+    msg := fmt.Sprintf("%v, %g, %g, %d", x, fx, ea, iter)
+    want := fmt.Sprintf("%v, %g, %g, %d < %d", xwant, fxwant, es, iter, maxit)
+    if err != nil || math.Abs(x[0] - xwant[0]) > 1e-8 || math.Abs(x[1] - xwant[1]) > 1e-8 || ea > es || iter >= maxit {
+        t.Fatalf(`Newton(rosenbrock, grad, hess, [-1.2, 1], nil, 1e-10, 100) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestNewtonIndefinite calls optimization.Newton with finite difference derivatives of the Rosenbrock function from
+// (0, 1) where the Hessian is indefinite, checking that the modified Cholesky step still reaches the minimum.
+func TestNewtonIndefinite(t *testing.T) {
+    f := func(x []float64) float64 {
+        return (1.0 - x[0])*(1.0 - x[0]) + 100.0*(x[1] - x[0]*x[0])*(x[1] - x[0]*x[0])
+    }
+    x, fx, ea, iter, err := Newton(f, nil, nil, []float64{0.0, 1.0}, nil, 1e-6, 100)
+    if err != nil || ea > 1e-6 || math.Abs(x[0] - 1.0) > 1e-5 || math.Abs(x[1] - 1.0) > 1e-5 {
+        t.Fatalf(`Newton(rosenbrock, nil, nil, [0, 1], nil, 1e-6, 100) = %v, %g, %g, %d, %v, want [1, 1]`, x, fx, ea, iter, err)
+    }
+}
+
+// TestTrustRegionNewton calls optimization.TrustRegionNewton with the Rosenbrock function, its gradient and Hessian,
+// an initial guess, initial radius, error limit and max iterations, checking for a valid return value.
+func TestTrustRegionNewton(t *testing.T) {
+    f := func(x []float64) float64 {
+        return (1.0 - x[0])*(1.0 - x[0]) + 100.0*(x[1] - x[0]*x[0])*(x[1] - x[0]*x[0])
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{-2.0*(1.0 - x[0]) - 400.0*x[0]*(x[1] - x[0]*x[0]), 200.0*(x[1] - x[0]*x[0])}
+    }
+    hess := func(x []float64) [][]float64 {
+        return [][]float64{{2.0 - 400.0*(x[1] - 3.0*x[0]*x[0]), -400.0*x[0]}, {-400.0*x[0], 200.0}}
+    }
+    x0 := []float64{-1.2, 1.0}
+    es := 1e-10
+    maxit := 200
+    x, fx, ea, iter, err := TrustRegionNewton(f, grad, hess, x0, 1.0, es, maxit)
+    if err != nil || math.Abs(x[0] - 1.0) > 1e-8 || math.Abs(x[1] - 1.0) > 1e-8 || ea > es || iter >= maxit {
+        t.Fatalf(`TrustRegionNewton(rosenbrock, grad, hess, [-1.2, 1], 1, 1e-10, 200) = %v, %g, %g, %d, %v, want [1, 1]`, x, fx, ea, iter, err)
+    }
+}
+
+// TestTrustRegionNewtonIndefinite calls optimization.TrustRegionNewton with a finite difference Hessian of the
+// Rosenbrock function from (0, 1) where the Hessian is indefinite, checking that the minimum is found.
+func TestTrustRegionNewtonIndefinite(t *testing.T) {
+    f := func(x []float64) float64 {
+        return (1.0 - x[0])*(1.0 - x[0]) + 100.0*(x[1] - x[0]*x[0])*(x[1] - x[0]*x[0])
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{-2.0*(1.0 - x[0]) - 400.0*x[0]*(x[1] - x[0]*x[0]), 200.0*(x[1] - x[0]*x[0])}
+    }
+    x, fx, ea, iter, err := TrustRegionNewton(f, grad, nil, []float64{0.0, 1.0}, 0.5, 1e-8, 200)
+    if err != nil || ea > 1e-8 || math.Abs(x[0] - 1.0) > 1e-7 || math.Abs(x[1] - 1.0) > 1e-7 {
+        t.Fatalf(`TrustRegionNewton(rosenbrock, grad, nil, [0, 1], 0.5, 1e-8, 200) = %v, %g, %g, %d, %v, want [1, 1]`, x, fx, ea, iter, err)
+    }
+}
+
+// TestTrustRegionNewtonRadius calls optimization.TrustRegionNewton with a zero radius, checking for an error.
+func TestTrustRegionNewtonRadius(t *testing.T) {
+    f := func(x []float64) float64 {
+        return (1.0 - x[0])*(1.0 - x[0]) + 100.0*(x[1] - x[0]*x[0])*(x[1] - x[0]*x[0])
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{-2.0*(1.0 - x[0]) - 400.0*x[0]*(x[1] - x[0]*x[0]), 200.0*(x[1] - x[0]*x[0])}
+    }
+    hess := func(x []float64) [][]float64 {
+        return [][]float64{{2.0 - 400.0*(x[1] - 3.0*x[0]*x[0]), -400.0*x[0]}, {-400.0*x[0], 200.0}}
+    }
+    x, fx, ea, iter, err := TrustRegionNewton(f, grad, hess, []float64{-1.2, 1.0}, 0.0, 1e-6, 100)
+    if x != nil || fx != 0.0 || ea != 0.0 || iter != 0 || err == nil {
+        t.Fatalf(`TrustRegionNewton(rosenbrock, grad, hess, x0, 0, 1e-6, 100) = %v, %f, %f, %d, %v, want nil, 0, 0, 0, error`, x, fx, ea, iter, err)
+    }
+}
+
+// TestNewtonLineSearchFailure calls optimization.Newton with a line search that always fails, checking that the
+// failure is returned as an error.
+func TestNewtonLineSearchFailure(t *testing.T) {
+    f := func(x []float64) float64 {
+        return (1.0 - x[0])*(1.0 - x[0]) + 100.0*(x[1] - x[0]*x[0])*(x[1] - x[0]*x[0])
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{-2.0*(1.0 - x[0]) - 400.0*x[0]*(x[1] - x[0]*x[0]), 200.0*(x[1] - x[0]*x[0])}
+    }
+    fail := func(phi func(float64) float64, dphi func(float64) float64, phi0 float64, dphi0 float64, alpha0 float64) (float64, float64, error) {
+        return 0.0, 0.0, errors.New("no step")
+    }
+    x, fx, ea, iter, err := Newton(f, grad, nil, []float64{-1.2, 1.0}, fail, 1e-6, 100)
+    if err == nil {
+        t.Fatalf(`Newton(rosenbrock, grad, nil, x0, fail, 1e-6, 100) = %v, %g, %g, %d, nil, want error`, x, fx, ea, iter)
+    }
+}
@@ -89,4 +89,324 @@ func Parabolic(f func(float64) float64, xl float64, xm float64, xu float64, es f
     x = x4 
     fx = f(x)
     return x, fx, ea, iter, nil
-}
\ No newline at end of file
+}
+
+// Brentmin (Brent's method for minimization)
+// A method that combines Golden-Section search and Parabolic interpolation, falling back to golden sections
+// whenever the parabolic step is not acceptable so convergence is guaranteed
+// input:
+// the function to find the minimum for (f), lower limit (xl), upper limit (xu), error deviation (es), maximum iterations (iter)
+// output: 
+// the estimated x (x), function value (fx), error estimate (ea), iterations done (iter)
+func Brentmin(f func(float64) float64, xl float64, xu float64, es float64, maxit int) (x float64, fx float64, ea float64, iter int, err error) {
+    if es < 0.0 {
+        return 0.0, 0.0, 0.0, 0, errors.New("es must be greater than 0")
+    }
+    if xl > xu {
+        xl, xu = xu, xl
+    }
+    c := (3.0 - math.Sqrt(5.0))/2.0
+    a := xl
+    b := xu
+    x = a + c*(b - a)
+    w := x
+    v := x
+    fx = f(x)
+    fw := fx
+    fv := fx
+    d := 0.0
+    e := 0.0
+    var m, scale, tol1, tol2, p, q, r, u, fu float64
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        m = 0.5*(a + b) // Termination test and possible exit
+        scale = math.Max(math.Abs(x), 1.0)
+        tol2 = es/100.0*scale // es and ea are relative errors in percent as in Goldmin
+        tol1 = math.Max(0.5*tol2, 1.5e-8*scale)
+        ea = math.Max(x - a, b - x)/scale*100.0
+        if ea <= es {
+            break;
+        }
+        golden := true
+        if math.Abs(e) > tol1 { // Try parabolic interpolation through x, w and v
+            r = (x - w)*(fx - fv)
+            q = (x - v)*(fx - fw)
+            p = (x - v)*q - (x - w)*r
+            q = 2.0*(q - r)
+            if q > 0.0 {
+                p = -p
+            } else {
+                q = -q
+            }
+            r = e
+            e = d
+            if math.Abs(p) < math.Abs(0.5*q*r) && p > q*(a - x) && p < q*(b - x) {
+                d = p/q
+                u = x + d
+                if u - a < tol2 || b - u < tol2 { // do not evaluate too close to the limits
+                    d = math.Copysign(tol1, m - x)
+                }
+                golden = false
+            }
+        }
+        if golden { // Golden-Section step into the larger segment
+            if x >= m {
+                e = a - x
+            } else {
+                e = b - x
+            }
+            d = c*e
+        }
+        if math.Abs(d) >= tol1 {
+            u = x + d
+        } else {
+            u = x + math.Copysign(tol1, d)
+        }
+        fu = f(u)
+        if fu <= fx {
+            if u >= x {
+                a = x
+            } else {
+                b = x
+            }
+            v, fv = w, fw
+            w, fw = x, fx
+            x, fx = u, fu
+        } else {
+            if u < x {
+                a = u
+            } else {
+                b = u
+            }
+            if fu <= fw || w == x {
+                v, fv = w, fw
+                w, fw = u, fu
+            } else if fu <= fv || v == x || v == w {
+                v, fv = u, fu
+            }
+        }
+    }
+    return x, fx, ea, iter, nil
+}
+
+
+// Mnbrak (Minimum bracketing)
+// Walks downhill from the starting points with golden section and parabolic extrapolation until
+// a triple with f(xl) >= f(xm) <= f(xu) is found
+// input:
+// the function to find a bracket for (f), first starting point (xa), second starting point (xb, if equal to xa a default step is taken), maximum iterations (iter)
+// output: 
+// lower limit (xl), intermediate point (xm), upper limit (xu) and their function values (fl, fm, fu)
+func Mnbrak(f func(float64) float64, xa float64, xb float64, maxit int) (xl float64, xm float64, xu float64, fl float64, fm float64, fu float64, err error) {
+    const glimit = 100.0
+    const tiny = 1e-20
+    phi := (1.0+math.Sqrt(5.0))/2.0
+    if xa == xb {
+        xb = xa + 0.1*math.Max(math.Abs(xa), 1.0)
+    }
+    a, b := xa, xb
+    fa, fb := f(a), f(b)
+    if fb > fa { // make sure the direction from a to b is downhill
+        a, b = b, a
+        fa, fb = fb, fa
+    }
+    c := b + phi*(b - a)
+    fc := f(c)
+    var r, q, u, ulim, fnew float64
+    iter := 0
+    for ; fb > fc; iter ++ {
+        if iter >= maxit {
+            return 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, errors.New("No minimum bracketed within maxit iterations")
+        }
+        r = (b - a)*(fb - fc) // parabolic extrapolation through a, b and c
+        q = (b - c)*(fb - fa)
+        u = b - ((b - c)*q - (b - a)*r)/(2.0*math.Copysign(math.Max(math.Abs(q - r), tiny), q - r))
+        ulim = b + glimit*(c - b)
+        if (b - u)*(u - c) > 0.0 { // parabolic u is between b and c
+            fnew = f(u)
+            if fnew < fc { // minimum between b and c
+                a, fa = b, fb
+                b, fb = u, fnew
+                break
+            } else if fnew > fb { // minimum between a and u
+                c, fc = u, fnew
+                break
+            }
+            u = c + phi*(c - b)
+            fnew = f(u)
+        } else if (c - u)*(u - ulim) > 0.0 { // parabolic u is between c and its allowed limit
+            fnew = f(u)
+            if fnew < fc {
+                b, fb = c, fc
+                c, fc = u, fnew
+                u = c + phi*(c - b)
+                fnew = f(u)
+            }
+        } else if (u - ulim)*(ulim - c) >= 0.0 { // limit parabolic u to its maximum allowed value
+            u = ulim
+            fnew = f(u)
+        } else { // reject parabolic u and use golden section magnification
+            u = c + phi*(c - b)
+            fnew = f(u)
+        }
+        a, b, c = b, c, u
+        fa, fb, fc = fb, fc, fnew
+    }
+    if a > c {
+        a, c = c, a
+        fa, fc = fc, fa
+    }
+    return a, b, c, fa, fb, fc, nil
+}
+
+// GoldminAuto (Golden-Section search with automatic bracketing)
+// input:
+// the function to find the minimum for (f), first starting point (xa), second starting point (xb), error deviation (es), maximum iterations (iter)
+// output: 
+// the estimated x (x), function value (fx), error estimate (ea), iterations done (iter)
+func GoldminAuto(f func(float64) float64, xa float64, xb float64, es float64, maxit int) (x float64, fx float64, ea float64, iter int, err error) {
+    xl, _, xu, _, _, _, err := Mnbrak(f, xa, xb, maxit)
+    if err != nil {
+        return 0.0, 0.0, 0.0, 0, err
+    }
+    return Goldmin(f, xl, xu, es, maxit)
+}
+
+// ParabolicAuto (Parabolic-Interpolation search with automatic bracketing)
+// input:
+// the function to find the minimum for (f), first starting point (xa), second starting point (xb), error deviation (es), maximum iterations (iter)
+// output: 
+// the estimated x (x), function value (fx), error estimate (ea), iterations done (iter)
+func ParabolicAuto(f func(float64) float64, xa float64, xb float64, es float64, maxit int) (x float64, fx float64, ea float64, iter int, err error) {
+    xl, xm, xu, _, _, _, err := Mnbrak(f, xa, xb, maxit)
+    if err != nil {
+        return 0.0, 0.0, 0.0, 0, err
+    }
+    return Parabolic(f, xl, xm, xu, es, maxit)
+}
+
+// Dbrentmin (Brent's method for minimization using derivatives)
+// A safeguarded method that tries a cubic Hermite interpolation step through the two best points, a secant step
+// on f' through the older point and falls back to bisection of the segment indicated by the sign of f'
+// input:
+// the function to find the minimum for (f), derivative function of f (df), lower limit (xl), upper limit (xu), error deviation (es), maximum iterations (iter)
+// output: 
+// the estimated x (x), function value (fx), error estimate (ea), iterations done (iter)
+func Dbrentmin(f func(float64) float64, df func(float64) float64, xl float64, xu float64, es float64, maxit int) (x float64, fx float64, ea float64, iter int, err error) {
+    if es < 0.0 {
+        return 0.0, 0.0, 0.0, 0, errors.New("es must be greater than 0")
+    }
+    if xl > xu {
+        xl, xu = xu, xl
+    }
+    a := xl
+    b := xu
+    x = 0.5*(a + b)
+    fx = f(x)
+    dx := df(x)
+    w, fw, dw := x, fx, dx
+    v, fv, dv := x, fx, dx
+    d := 0.0
+    e := 0.0
+    var m, scale, tol1, tol2, d1, d2, u, fu, du, olde float64
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        m = 0.5*(a + b) // Termination test and possible exit
+        scale = math.Max(math.Abs(x), 1.0)
+        tol2 = es/100.0*scale
+        tol1 = math.Max(0.5*tol2, 1.5e-8*scale)
+        ea = math.Max(x - a, b - x)/scale*100.0
+        if ea <= es {
+            break;
+        }
+        bisect := true
+        if math.Abs(e) > tol1 {
+            d1 = 2.0*(b - a) // out of range values mark the steps as unusable
+            d2 = d1
+            if w != x { // Cubic Hermite interpolation through x and w, secant on f' if the cubic has no minimum
+                c1 := dx + dw - 3.0*(fx - fw)/(x - w)
+                c2 := c1*c1 - dx*dw
+                if c2 >= 0.0 {
+                    c2 = math.Copysign(math.Sqrt(c2), w - x)
+                    if den := dw - dx + 2.0*c2; den != 0.0 {
+                        d1 = (w - (w - x)*(dw + c2 - c1)/den) - x
+                    }
+                } else if dw != dx {
+                    d1 = (w - x)*dx/(dx - dw)
+                }
+            }
+            if v != x && dv != dx { // Secant on f' through x and v
+                d2 = (v - x)*dx/(dx - dv)
+            }
+            u1 := x + d1
+            u2 := x + d2
+            ok1 := (a - u1)*(u1 - b) > 0.0 && dx*d1 <= 0.0 // inside the bracket and downhill
+            ok2 := (a - u2)*(u2 - b) > 0.0 && dx*d2 <= 0.0
+            olde = e
+            e = d
+            if ok1 || ok2 {
+                if ok1 && ok2 {
+                    if math.Abs(d1) < math.Abs(d2) {
+                        d = d1
+                    } else {
+                        d = d2
+                    }
+                } else if ok1 {
+                    d = d1
+                } else {
+                    d = d2
+                }
+                if math.Abs(d) <= math.Abs(0.5*olde) {
+                    u = x + d
+                    if u - a < tol2 || b - u < tol2 {
+                        d = math.Copysign(tol1, m - x)
+                    }
+                    bisect = false
+                }
+            }
+        }
+        if bisect { // Bisection of the segment the derivative points into, the larger one at a stationary point
+            if dx > 0.0 || (dx == 0.0 && x - a >= b - x) {
+                e = a - x
+            } else {
+                e = b - x
+            }
+            d = 0.5*e
+        }
+        if math.Abs(d) >= tol1 {
+            u = x + d
+            fu = f(u)
+        } else {
+            u = x + math.Copysign(tol1, d)
+            fu = f(u)
+            if fu > fx { // the minimum step went uphill, so x is converged
+                ea = tol1/scale*100.0
+                break;
+            }
+        }
+        du = df(u)
+        if fu <= fx {
+            if u >= x {
+                a = x
+            } else {
+                b = x
+            }
+            v, fv, dv = w, fw, dw
+            w, fw, dw = x, fx, dx
+            x, fx, dx = u, fu, du
+        } else {
+            if u < x {
+                a = u
+            } else {
+                b = u
+            }
+            if fu <= fw || w == x {
+                v, fv, dv = w, fw, dw
+                w, fw, dw = u, fu, du
+            } else if fu < fv || v == x || v == w {
+                v, fv, dv = u, fu, du
+            }
+        }
+    }
+    return x, fx, ea, iter, nil
+}
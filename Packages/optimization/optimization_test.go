@@ -50,3 +50,163 @@ func TestParabolic(t *testing.T) {
         t.Fatalf(`Parabolic(f, 0, 1, 4, 1e-4, 50) = %q, %v, want match for %#v, nil`, msg, err, want)
     }
 }
+
+
+// TestBrentmin calls optimization.Brentmin with a function, x lower, x upper, error limit and max iterations, checking 
+// for a valid return value.
+func TestBrentmin(t *testing.T) {
+    xl := 0.0
+    xu := 4.0
+    es := 1e-4
+    maxit := 50
+    f := func(x float64) float64 {
+        return (x*x)/10.0 - 2.0*math.Sin(x)
+    }
+    xwant := 1.4276
+    fxwant := -1.7757
+    x, fx, ea, iter, err := Brentmin(f, xl, xu, es, maxit)
+    msg := fmt.Sprintf("%f, %f, %f, %d", x, fx, ea, iter)
+    want := fmt.Sprintf("%f, %f, %f, %d < %d", xwant, fxwant, es, iter, maxit)
+    xwithininterval := (x <= xwant + es) && (x >= xwant - es)
+    fxwithininterval := (fx <= fxwant + es) && (fx >= fxwant - es)
+    if !xwithininterval || !fxwithininterval || ea > es || iter >= maxit || err != nil {
+        t.Fatalf(`Brentmin(f, 0, 4, 1e-4, 50) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestBrentminFlat calls optimization.Brentmin with a function where Parabolic stops early (f4 >= f2 on
+// the first step), checking that the minimum is still found.
+func TestBrentminFlat(t *testing.T) {
+    xl := -1.0
+    xu := 3.0
+    es := 1e-4 // percent
+    maxit := 100
+    f := func(x float64) float64 {
+        return math.Abs(x - 2.0) + 0.1*(x - 2.0)*(x - 2.0)
+    }
+    xwant := 2.0
+    x, fx, ea, iter, err := Brentmin(f, xl, xu, es, maxit)
+    if math.Abs(x - xwant) > 1e-5 || ea > es || iter >= maxit || err != nil {
+        t.Fatalf(`Brentmin(f: x->|x-2|+0.1(x-2)^2, -1, 3, 1e-4, 100) = %f, %f, %f, %d, %v, want %f`, x, fx, ea, iter, err, xwant)
+    }
+}
+
+
+// TestMnbrak calls optimization.Mnbrak with a function and starting points far from the minimum, checking 
+// that a valid bracket is returned.
+func TestMnbrak(t *testing.T) {
+    f := func(x float64) float64 {
+        return (x - 30.0)*(x - 30.0) + math.Cos(x)
+    }
+    for _, start := range [][2]float64{{0.0, 1.0}, {1.0, 0.0}, {100.0, 100.0}} {
+        xl, xm, xu, fl, fm, fu, err := Mnbrak(f, start[0], start[1], 50)
+        msg := fmt.Sprintf("%f, %f, %f, %f, %f, %f", xl, xm, xu, fl, fm, fu)
+        if err != nil || !(xl < xm && xm < xu) || fm > fl || fm > fu || xl > 30.0 || xu < 29.0 {
+            t.Fatalf(`Mnbrak(f, %f, %f, 50) = %q, %v, want xl < xm < xu and f(xl) >= f(xm) <= f(xu), nil`, start[0], start[1], msg, err)
+        }
+    }
+}
+
+// TestMnbrakMonotone calls optimization.Mnbrak with a function without a minimum, 
+// checking for an error.
+func TestMnbrakMonotone(t *testing.T) {
+    f := func(x float64) float64 {
+        return -x
+    }
+    xl, xm, xu, fl, fm, fu, err := Mnbrak(f, 0.0, 1.0, 20)
+    if err == nil {
+        t.Fatalf(`Mnbrak(f: x->-x, 0, 1, 20) = %f, %f, %f, %f, %f, %f, %v, want error`, xl, xm, xu, fl, fm, fu, err)
+    }
+}
+
+// TestGoldminAuto calls optimization.GoldminAuto and optimization.ParabolicAuto with starting points outside
+// the interval used by TestGoldmin, checking for a valid return value.
+func TestGoldminAuto(t *testing.T) {
+    es := 1e-4
+    maxit := 50
+    f := func(x float64) float64 {
+        return (x*x)/10.0 - 2.0*math.Sin(x)
+    }
+    xwant := 1.4276
+    fxwant := -1.7757
+    x, fx, ea, iter, err := GoldminAuto(f, -1.0, -0.5, es, maxit)
+    msg := fmt.Sprintf("%f, %f, %f, %d", x, fx, ea, iter)
+    want := fmt.Sprintf("%f, %f, %f, %d < %d", xwant, fxwant, es, iter, maxit)
+    if math.Abs(x - xwant) > es || math.Abs(fx - fxwant) > es || ea > es || iter > maxit || err != nil {
+        t.Fatalf(`GoldminAuto(f, -1, -0.5, 1e-4, 50) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+    x, fx, ea, iter, err = ParabolicAuto(f, -1.0, -0.5, es, maxit)
+    msg = fmt.Sprintf("%f, %f, %f, %d", x, fx, ea, iter)
+    if math.Abs(x - xwant) > es || math.Abs(fx - fxwant) > es || ea > es || iter > maxit || err != nil {
+        t.Fatalf(`ParabolicAuto(f, -1, -0.5, 1e-4, 50) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestDbrentmin calls optimization.Dbrentmin with a function, its derivative, x lower, x upper, error limit and max iterations, checking 
+// for a valid return value.
+func TestDbrentmin(t *testing.T) {
+    xl := 0.0
+    xu := 4.0
+    es := 1e-4
+    maxit := 50
+    f := func(x float64) float64 {
+        return (x*x)/10.0 - 2.0*math.Sin(x)
+    }
+    df := func(x float64) float64 {
+        return x/5.0 - 2.0*math.Cos(x)
+    }
+    xwant := 1.4276
+    fxwant := -1.7757
+    x, fx, ea, iter, err := Dbrentmin(f, df, xl, xu, es, maxit)
+    msg := fmt.Sprintf("%f, %f, %f, %d", x, fx, ea, iter)
+    want := fmt.Sprintf("%f, %f, %f, %d < %d", xwant, fxwant, es, iter, maxit)
+    xwithininterval := (x <= xwant + es) && (x >= xwant - es)
+    fxwithininterval := (fx <= fxwant + es) && (fx >= fxwant - es)
+    if !xwithininterval || !fxwithininterval || ea > es || iter >= maxit || err != nil {
+        t.Fatalf(`Dbrentmin(f, df, 0, 4, 1e-4, 50) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestDbrentminQuartic calls optimization.Dbrentmin with a quartic on a wide interval, checking that the
+// minimum at x = 0.8 is found to a tight tolerance.
+func TestDbrentminQuartic(t *testing.T) {
+    f := func(x float64) float64 {
+        return math.Pow(x - 0.3, 4.0) + 0.5*(x - 0.3)*(x - 0.3) - x
+    }
+    df := func(x float64) float64 {
+        return 4.0*math.Pow(x - 0.3, 3.0) + (x - 0.3) - 1.0
+    }
+    x, fx, ea, iter, err := Dbrentmin(f, df, -5.0, 5.0, 1e-10, 100)
+    if math.Abs(x - 0.8) > 1e-7 || iter >= 100 || err != nil {
+        t.Fatalf(`Dbrentmin(f, df, -5, 5, 1e-10, 100) = %f, %f, %g, %d, %v, want 0.8`, x, fx, ea, iter, err)
+    }
+}
+
+// TestDbrentminStationaryStart calls optimization.Dbrentmin with cos on [-2, 2], where the starting midpoint is the
+// maximum, checking that the minimum at one of the limits is found.
+func TestDbrentminStationaryStart(t *testing.T) {
+    es := 1e-4
+    maxit := 50
+    df := func(x float64) float64 {
+        return -math.Sin(x)
+    }
+    x, fx, ea, iter, err := Dbrentmin(math.Cos, df, -2.0, 2.0, es, maxit)
+    if math.Abs(math.Abs(x) - 2.0) > 1e-4 || math.Abs(fx - math.Cos(2.0)) > 1e-4 || ea > es || iter == 0 || iter >= maxit || err != nil {
+        t.Fatalf(`Dbrentmin(cos, -sin, -2, 2, 1e-4, 50) = %f, %f, %g, %d, %v, want +-2, %f`, x, fx, ea, iter, err, math.Cos(2.0))
+    }
+}
+
+// TestDbrentminPrecision calls optimization.Dbrentmin with (x - 1)^2 on [0, 3] and an error limit below the
+// attainable precision, checking that the minimum is found and that ea reports the attained precision in percent.
+func TestDbrentminPrecision(t *testing.T) {
+    f := func(x float64) float64 {
+        return (x - 1.0)*(x - 1.0)
+    }
+    df := func(x float64) float64 {
+        return 2.0*(x - 1.0)
+    }
+    x, fx, ea, iter, err := Dbrentmin(f, df, 0.0, 3.0, 1e-12, 50)
+    if math.Abs(x - 1.0) > 1e-8 || ea <= 0.0 || ea > 1.5e-6 || iter >= 50 || err != nil {
+        t.Fatalf(`Dbrentmin(f, df, 0, 3, 1e-12, 50) = %g, %g, %g, %d, %v, want 1, 0, at most 1.5e-6, iter < 50, nil`, x, fx, ea, iter, err)
+    }
+}
@@ -0,0 +1,160 @@
+package optimization
+
+import (
+    "errors"
+    "math"
+    "math/rand"
+)
+
+// PSOVariant selects the velocity update of particle swarm optimization
+type PSOVariant int
+
+const (
+    // PSOInertia uses v = w v + c1 r1 (p - x) + c2 r2 (g - x) with c1 = c2 = 2 and w decreasing linearly from 0.9 to 0.4
+    PSOInertia PSOVariant = iota
+    // PSOConstriction uses v = chi (v + c1 r1 (p - x) + c2 r2 (g - x)) with c1 = c2 = 2.05 and chi = 0.7298 (Clerc and Kennedy)
+    PSOConstriction
+)
+
+// PSOTopology selects which particles share their best positions
+type PSOTopology int
+
+const (
+    // PSOGlobal lets every particle follow the best position of the whole swarm
+    PSOGlobal PSOTopology = iota
+    // PSORing lets every particle follow the best position of itself and its two neighbors, which converges slower but explores more
+    PSORing
+)
+
+// PSOBoundary selects what happens to a particle leaving the box
+type PSOBoundary int
+
+const (
+    // PSOClamp puts the particle on the violated bound and stops its velocity component
+    PSOClamp PSOBoundary = iota
+    // PSOReflect mirrors the particle at the violated bound and reverses its velocity component
+    PSOReflect
+    // PSORandom places the component uniformly at random in the box and stops its velocity component
+    PSORandom
+)
+
+// ParticleSwarm (Particle swarm optimization for bounded multivariate functions)
+// Moves np particles through the box [lower, upper] attracted by their own best position and the best position of
+// their neighborhood. The velocity components are limited to vmax times the width of the box when vmax > 0. The new
+// positions are generated from the seeded random number generator before they are evaluated by up to workers
+// goroutines, so the result does not depend on workers.
+// input:
+// the function to find the minimum for (f), lower bounds (lower), upper bounds (upper), swarm size of at least 2 (np), velocity update (variant), neighborhood topology (topology), boundary handling (boundary), velocity limit relative to the box width, 0 for none (vmax), seed of the random number generator (seed), number of goroutines evaluating f (workers), relative spread of the best function values of the particles (es), maximum iterations (iter)
+// output:
+// the best x (x), function value (fx), relative spread of the best function values of the particles (ea), iterations done (iter)
+func ParticleSwarm(f func([]float64) float64, lower []float64, upper []float64, np int, variant PSOVariant, topology PSOTopology, boundary PSOBoundary, vmax float64, seed int64, workers int, es float64, maxit int) (x []float64, fx float64, ea float64, iter int, err error) {
+    if err = checkBox(lower, upper); err != nil {
+        return nil, 0.0, 0.0, 0, err
+    }
+    if np < 2 {
+        return nil, 0.0, 0.0, 0, errors.New("np must be at least 2")
+    }
+    if variant < PSOInertia || variant > PSOConstriction {
+        return nil, 0.0, 0.0, 0, errors.New("Unknown particle swarm variant")
+    }
+    if topology < PSOGlobal || topology > PSORing {
+        return nil, 0.0, 0.0, 0, errors.New("Unknown particle swarm topology")
+    }
+    if boundary < PSOClamp || boundary > PSORandom {
+        return nil, 0.0, 0.0, 0, errors.New("Unknown particle swarm boundary handling")
+    }
+    if es < 0.0 {
+        return nil, 0.0, 0.0, 0, errors.New("es must be greater than 0")
+    }
+    n := len(lower)
+    rng := rand.New(rand.NewSource(seed))
+    pos := make([][]float64, np)
+    vel := make([][]float64, np)
+    for i := range pos {
+        pos[i] = make([]float64, n)
+        vel[i] = make([]float64, n)
+        for j := range pos[i] {
+            pos[i][j] = lower[j] + rng.Float64()*(upper[j] - lower[j])
+            vel[i][j] = 0.5*(lower[j] + rng.Float64()*(upper[j] - lower[j]) - pos[i][j])
+        }
+    }
+    fpos := evaluate(f, pos, workers)
+    pbest := make([][]float64, np)
+    fbest := make([]float64, np)
+    for i := range pos {
+        pbest[i] = append([]float64(nil), pos[i]...)
+        fbest[i] = fpos[i]
+    }
+    spread := func() (int, float64) {
+        best := 0
+        worst := 0
+        for i := range fbest {
+            if fbest[i] < fbest[best] {
+                best = i
+            }
+            if fbest[i] > fbest[worst] {
+                worst = i
+            }
+        }
+        return best, (fbest[worst] - fbest[best])/math.Max(math.Abs(fbest[best]), 1.0)
+    }
+    best, ea := spread()
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        if ea <= es {
+            break
+        }
+        w, c, chi := 0.9 - 0.5*float64(iter)/float64(maxit), 2.0, 1.0
+        if variant == PSOConstriction {
+            w, c, chi = 1.0, 2.05, 0.7298
+        }
+        for i := 0; i < np; i++ {
+            g := best
+            if topology == PSORing {
+                g = i
+                for _, k := range []int{(i + np - 1)%np, (i + 1)%np} {
+                    if fbest[k] < fbest[g] {
+                        g = k
+                    }
+                }
+            }
+            for j := 0; j < n; j++ {
+                width := upper[j] - lower[j]
+                r1, r2 := rng.Float64(), rng.Float64()
+                v := chi*(w*vel[i][j] + c*r1*(pbest[i][j] - pos[i][j]) + c*r2*(pbest[g][j] - pos[i][j]))
+                if vmax > 0.0 {
+                    v = math.Min(math.Max(v, -vmax*width), vmax*width)
+                }
+                p := pos[i][j] + v
+                if p < lower[j] || p > upper[j] {
+                    switch boundary {
+                    case PSOReflect:
+                        if p < lower[j] {
+                            p = 2.0*lower[j] - p
+                        } else {
+                            p = 2.0*upper[j] - p
+                        }
+                        p = math.Min(math.Max(p, lower[j]), upper[j])
+                        v = -v
+                    case PSORandom:
+                        p = lower[j] + rng.Float64()*width
+                        v = 0.0
+                    default:
+                        p = math.Min(math.Max(p, lower[j]), upper[j])
+                        v = 0.0
+                    }
+                }
+                pos[i][j], vel[i][j] = p, v
+            }
+        }
+        fpos = evaluate(f, pos, workers)
+        for i := range pos {
+            if fpos[i] < fbest[i] {
+                copy(pbest[i], pos[i])
+                fbest[i] = fpos[i]
+            }
+        }
+        best, ea = spread()
+    }
+    return pbest[best], fbest[best], ea, iter, nil
+}
@@ -0,0 +1,103 @@
+package optimization
+
+import (
+    "testing"
+    "fmt"
+    "math"
+)
+
+// TestParticleSwarm calls optimization.ParticleSwarm with the two dimensional Rastrigin function for both velocity
+// updates and both topologies, checking for the global minimum.
+func TestParticleSwarm(t *testing.T) {
+    rastrigin := func(x []float64) float64 { // global minimum 0 at the origin surrounded by a grid of local minima
+        s := 10.0*float64(len(x))
+        for _, v := range x {
+            s += v*v - 10.0*math.Cos(2.0*math.Pi*v)
+        }
+        return s
+    }
+    lower := []float64{-5.12, -5.12}
+    upper := []float64{5.12, 5.12}
+    es := 1e-10
+    maxit := 3000
+    for _, variant := range []PSOVariant{PSOInertia, PSOConstriction} {
+        for _, topology := range []PSOTopology{PSOGlobal, PSORing} {
+            x, fx, ea, iter, err := ParticleSwarm(rastrigin, lower, upper, 30, variant, topology, PSOClamp, 0.2, 1, 1, es, maxit)
+            msg := fmt.Sprintf("%v, %g, %g, %d", x, fx, ea, iter)
+            want := fmt.Sprintf("[0 0], 0, %g, %d < %d", es, iter, maxit)
+            if err != nil || norminf(x) > 1e-6 || fx > 1e-9 || ea > es || iter >= maxit {
+                t.Fatalf(`ParticleSwarm(rastrigin, lower, upper, 30, %d, %d, PSOClamp, 0.2, 1, 1, 1e-10, 3000) = %q, %v, want match for %#v, nil`, variant, topology, msg, err, want)
+            }
+        }
+    }
+}
+
+// TestParticleSwarmBoundary calls optimization.ParticleSwarm with a quadratic whose minimum over [-1, 1]^2 lies in the
+// corner (-1, 1) for every boundary handling, checking for the corner.
+func TestParticleSwarmBoundary(t *testing.T) {
+    f := func(x []float64) float64 {
+        return (x[0] + 2.0)*(x[0] + 2.0) + (x[1] - 3.0)*(x[1] - 3.0)
+    }
+    lower := []float64{-1.0, -1.0}
+    upper := []float64{1.0, 1.0}
+    for _, boundary := range []PSOBoundary{PSOClamp, PSOReflect, PSORandom} {
+        tol := 1e-6
+        if boundary == PSORandom { // resampled components never land exactly on the bound
+            tol = 1e-3
+        }
+        x, fx, ea, iter, err := ParticleSwarm(f, lower, upper, 20, PSOConstriction, PSOGlobal, boundary, 0.0, 1, 1, 1e-12, 2000)
+        msg := fmt.Sprintf("%v, %g, %g, %d", x, fx, ea, iter)
+        want := "[-1 1], 5, ea, iter"
+        if err != nil || math.Abs(x[0] + 1.0) > tol || math.Abs(x[1] - 1.0) > tol || math.Abs(fx - 5.0) > 10.0*tol {
+            t.Fatalf(`ParticleSwarm(f, lower, upper, 20, PSOConstriction, PSOGlobal, %d, 0, 1, 1, 1e-12, 2000) = %q, %v, want match for %#v, nil`, boundary, msg, err, want)
+        }
+    }
+}
+
+// TestParticleSwarmWorkers calls optimization.ParticleSwarm with one and with four workers,
+// checking that the results are identical.
+func TestParticleSwarmWorkers(t *testing.T) {
+    rastrigin := func(x []float64) float64 { // global minimum 0 at the origin surrounded by a grid of local minima
+        s := 10.0*float64(len(x))
+        for _, v := range x {
+            s += v*v - 10.0*math.Cos(2.0*math.Pi*v)
+        }
+        return s
+    }
+    lower := []float64{-5.12, -5.12, -5.12}
+    upper := []float64{5.12, 5.12, 5.12}
+    x1, fx1, ea1, iter1, err1 := ParticleSwarm(rastrigin, lower, upper, 20, PSOInertia, PSORing, PSOReflect, 0.5, 9, 1, 1e-8, 100)
+    x4, fx4, ea4, iter4, err4 := ParticleSwarm(rastrigin, lower, upper, 20, PSOInertia, PSORing, PSOReflect, 0.5, 9, 4, 1e-8, 100)
+    if err1 != nil || err4 != nil || fmt.Sprint(x1) != fmt.Sprint(x4) || fx1 != fx4 || ea1 != ea4 || iter1 != iter4 {
+        t.Fatalf(`ParticleSwarm with 4 workers = %v, %g, %g, %d, %v, want %v, %g, %g, %d, %v`, x4, fx4, ea4, iter4, err4, x1, fx1, ea1, iter1, err1)
+    }
+}
+
+// TestParticleSwarmErrors calls optimization.ParticleSwarm with invalid bounds, swarm size, variant, topology and
+// boundary handling, checking for errors.
+func TestParticleSwarmErrors(t *testing.T) {
+    rastrigin := func(x []float64) float64 { // global minimum 0 at the origin surrounded by a grid of local minima
+        s := 10.0*float64(len(x))
+        for _, v := range x {
+            s += v*v - 10.0*math.Cos(2.0*math.Pi*v)
+        }
+        return s
+    }
+    lower := []float64{-1.0, -1.0}
+    upper := []float64{1.0, 1.0}
+    calls := []struct {
+        lower    []float64
+        upper    []float64
+        np       int
+        variant  PSOVariant
+        topology PSOTopology
+        boundary PSOBoundary
+    }{{upper, lower, 10, PSOInertia, PSOGlobal, PSOClamp}, {lower, upper, 1, PSOInertia, PSOGlobal, PSOClamp}, {lower, upper, 10, PSOVariant(2), PSOGlobal, PSOClamp},
+        {lower, upper, 10, PSOInertia, PSOTopology(-1), PSOClamp}, {lower, upper, 10, PSOInertia, PSOGlobal, PSOBoundary(3)}}
+    for _, c := range calls {
+        x, fx, ea, iter, err := ParticleSwarm(rastrigin, c.lower, c.upper, c.np, c.variant, c.topology, c.boundary, 0.0, 1, 1, 1e-8, 10)
+        if x != nil || fx != 0.0 || ea != 0.0 || iter != 0 || err == nil {
+            t.Fatalf(`ParticleSwarm(rastrigin, %v, %v, %d, %d, %d, %d, 0, 1, 1, 1e-8, 10) = %v, %g, %g, %d, %v, want nil, 0, 0, 0, error`, c.lower, c.upper, c.np, c.variant, c.topology, c.boundary, x, fx, ea, iter, err)
+        }
+    }
+}
@@ -0,0 +1,459 @@
+package optimization
+
+import (
+    "errors"
+    "math"
+)
+
+// dualActiveSet solves min x'Gx/2 + a'x subject to C[i]'x = b[i] for i < meq and C[i]'x >= b[i] for i >= meq
+// with the dual active set method of Goldfarb and Idnani, G must be positive definite. The constraints listed in
+// start are added to the active set first (after the equalities) which warm starts the method from a guessed active set.
+// The multipliers u satisfy G x + a = sum u[i] C[i] and u[i] >= 0 for the inequalities. When the constraints are
+// infeasible the returned ray is a nonnegative combination y of the constraints with sum y[i] C[i] = 0 and sum y[i] b[i] > 0.
+func dualActiveSet(G [][]float64, a []float64, C [][]float64, b []float64, meq int, start []int) (x []float64, u []float64, active []int, ray []float64, err error) {
+    n := len(a)
+    m := len(C)
+    L, ok := cholesky(G)
+    if !ok {
+        return nil, nil, nil, nil, errors.New("The quadratic term must be positive definite")
+    }
+    x = choleskySolve(L, a)
+    for i := range x {
+        x[i] = -x[i]
+    }
+    var normals [][]float64 // normals of the active constraints, equalities may be sign flipped
+    var signs []float64
+    var mult []float64
+    isactive := make([]bool, m)
+    scale := make([]float64, m)
+    for i := range C {
+        scale[i] = math.Max(math.Sqrt(dot(C[i], C[i])), 1e-300)
+    }
+    queue := make([]int, 0, meq + len(start))
+    for i := 0; i < meq; i++ {
+        queue = append(queue, i)
+    }
+    for _, i := range start {
+        if i >= meq && i < m {
+            queue = append(queue, i)
+        }
+    }
+    drop := func(k int) {
+        isactive[active[k]] = false
+        active = append(active[:k], active[k+1:]...)
+        normals = append(normals[:k], normals[k+1:]...)
+        signs = append(signs[:k], signs[k+1:]...)
+        mult = append(mult[:k], mult[k+1:]...)
+    }
+    const tol = 1e-12
+    for outer := 0; outer < 10*(n + m) + 10; outer++ {
+        // Choose a violated constraint p, queued constraints first, then the most violated inequality
+        p := -1
+        for len(queue) > 0 && p < 0 {
+            if !isactive[queue[0]] {
+                p = queue[0]
+            }
+            queue = queue[1:]
+        }
+        if p < 0 {
+            worst := -tol
+            for i := meq; i < m; i++ {
+                if !isactive[i] {
+                    if s := (dot(C[i], x) - b[i])/scale[i]; s < worst {
+                        worst, p = s, i
+                    }
+                }
+            }
+        }
+        if p < 0 { // all constraints satisfied, x is optimal
+            u = make([]float64, m)
+            for k, i := range active {
+                u[i] = signs[k]*mult[k]
+            }
+            return x, u, active, nil, nil
+        }
+        np := C[p]
+        bp := b[p]
+        sign := 1.0
+        if p < meq && dot(np, x) - bp > 0.0 {
+            sign = -1.0
+            np = make([]float64, n)
+            for i := range np {
+                np[i] = -C[p][i]
+            }
+            bp = -bp
+        }
+        uplus := 0.0
+        for inner := 0; inner < 10*(n + m) + 10; inner++ {
+            // Step direction in primal (z) and dual (r) space
+            q := len(normals)
+            ginvn := choleskySolve(L, np)
+            z := append([]float64(nil), ginvn...)
+            r := make([]float64, q)
+            if q > 0 {
+                ginvN := make([][]float64, q)
+                M := make([][]float64, q)
+                for j := range normals {
+                    ginvN[j] = choleskySolve(L, normals[j])
+                }
+                rhs := make([]float64, q)
+                for j := range normals {
+                    M[j] = make([]float64, q)
+                    for k := range normals {
+                        M[j][k] = dot(normals[j], ginvN[k])
+                    }
+                    rhs[j] = dot(normals[j], ginvn)
+                }
+                LM, ok := cholesky(M)
+                if !ok {
+                    return nil, nil, nil, nil, errors.New("The active constraints are linearly dependent")
+                }
+                r = choleskySolve(LM, rhs)
+                for j := range normals {
+                    for i := range z {
+                        z[i] -= r[j]*ginvN[j][i]
+                    }
+                }
+            }
+            // Partial step length keeping the inequality multipliers nonnegative
+            t1 := math.Inf(1)
+            k := -1
+            for j := range active {
+                if active[j] >= meq && r[j] > 0.0 {
+                    if t := mult[j]/r[j]; t < t1 {
+                        t1, k = t, j
+                    }
+                }
+            }
+            // Full step length making constraint p active
+            t2 := math.Inf(1)
+            sp := dot(np, x) - bp
+            zn := dot(z, np)
+            if math.Sqrt(dot(z, z)) > 1e-14*math.Max(norminf(x), 1.0) && zn > 0.0 {
+                t2 = -sp/zn
+            }
+            t := math.Min(t1, t2)
+            if math.IsInf(t, 1) && p < meq && math.Abs(sp) <= tol*scale[p] { // a dependent equality that already holds
+                break
+            }
+            if math.IsInf(t, 1) { // no step possible, the constraints are infeasible
+                ray = make([]float64, m)
+                ray[p] = sign
+                for j, i := range active {
+                    ray[i] = -signs[j]*r[j]
+                }
+                return nil, nil, nil, ray, errors.New("The constraints are infeasible")
+            }
+            if math.IsInf(t2, 1) { // dual step only, drop the blocking constraint
+                for j := range mult {
+                    mult[j] -= t*r[j]
+                }
+                uplus += t
+                drop(k)
+                continue
+            }
+            for i := range x {
+                x[i] += t*z[i]
+            }
+            for j := range mult {
+                mult[j] -= t*r[j]
+            }
+            uplus += t
+            if t == t2 { // full step, p becomes active
+                active = append(active, p)
+                normals = append(normals, np)
+                signs = append(signs, sign)
+                mult = append(mult, uplus)
+                isactive[p] = true
+                break
+            }
+            drop(k)
+        }
+    }
+    return nil, nil, nil, nil, errors.New("Maximum number of active set changes reached")
+}
+
+// checkQP validates the dimensions of min x'Qx/2 + c'x subject to A x <= b and Aeq x = beq
+func checkQP(Q [][]float64, c []float64, A [][]float64, b []float64, Aeq [][]float64, beq []float64) error {
+    n := len(c)
+    if n == 0 {
+        return errors.New("c must not be empty")
+    }
+    if len(Q) != n {
+        return errors.New("Q must be a square matrix of the size of c")
+    }
+    for _, row := range Q {
+        if len(row) != n {
+            return errors.New("Q must be a square matrix of the size of c")
+        }
+    }
+    if len(A) != len(b) {
+        return errors.New("A and b must have the same number of rows")
+    }
+    if len(Aeq) != len(beq) {
+        return errors.New("Aeq and beq must have the same number of rows")
+    }
+    for _, row := range append(append([][]float64(nil), A...), Aeq...) {
+        if len(row) != n {
+            return errors.New("Every row of A and Aeq must have one entry per variable")
+        }
+    }
+    return nil
+}
+
+// quadratic returns x'Qx/2 + c'x
+func quadratic(Q [][]float64, c []float64, x []float64) float64 {
+    return 0.5*dot(x, matvec(Q, x)) + dot(c, x)
+}
+
+// ActiveSetQP (Dual active set method for convex quadratic programs)
+// Solves min x'Qx/2 + c'x subject to A x <= b and Aeq x = beq with the method of Goldfarb and Idnani, Q must be positive
+// definite. The multipliers y of the rows of A followed by Aeq satisfy Qx + c + A'y_A + Aeq'y_eq = 0 with y_A >= 0.
+// Passing the active rows of a previous solve as start warm starts the method. When the constraints are infeasible the
+// error is accompanied by a ray r with r_A >= 0, A'r_A + Aeq'r_eq = 0 and b'r_A + beq'r_eq < 0.
+// input:
+// the quadratic term (Q), the linear term (c), the inequality matrix (A, nil for none), its right hand side (b), the equality matrix (Aeq, nil for none), its right hand side (beq), rows of A guessed to be active (start, nil for none)
+// output:
+// the optimal x (x), function value (fx), multipliers of the rows of A and Aeq (y), rows of A active at x (active), infeasibility certificate (ray)
+func ActiveSetQP(Q [][]float64, c []float64, A [][]float64, b []float64, Aeq [][]float64, beq []float64, start []int) (x []float64, fx float64, y []float64, active []int, ray []float64, err error) {
+    err = checkQP(Q, c, A, b, Aeq, beq)
+    if err != nil {
+        return nil, 0.0, nil, nil, nil, err
+    }
+    mi, me := len(A), len(Aeq)
+    // Goldfarb-Idnani form C'x >= d with the equalities first
+    C := make([][]float64, 0, me + mi)
+    d := make([]float64, 0, me + mi)
+    for e := range Aeq {
+        C = append(C, Aeq[e])
+        d = append(d, beq[e])
+    }
+    for i := range A {
+        row := make([]float64, len(c))
+        for j := range row {
+            row[j] = -A[i][j]
+        }
+        C = append(C, row)
+        d = append(d, -b[i])
+    }
+    guess := make([]int, len(start))
+    for k, i := range start {
+        guess[k] = i + me
+    }
+    x, u, working, gray, err := dualActiveSet(Q, c, C, d, me, guess)
+    if gray != nil {
+        ray = make([]float64, mi + me)
+        for i := range A {
+            ray[i] = gray[me+i]
+        }
+        for e := range Aeq {
+            ray[mi+e] = -gray[e]
+        }
+        return nil, 0.0, nil, nil, ray, err
+    }
+    if err != nil {
+        return nil, 0.0, nil, nil, nil, err
+    }
+    y = make([]float64, mi + me)
+    for i := range A {
+        y[i] = u[me+i]
+    }
+    for e := range Aeq {
+        y[mi+e] = -u[e]
+    }
+    for _, k := range working {
+        if k >= me {
+            active = append(active, k - me)
+        }
+    }
+    return x, quadratic(Q, c, x), y, active, nil, nil
+}
+
+// ADMMQP (Alternating direction method of multipliers for convex quadratic programs)
+// Solves min x'Qx/2 + c'x subject to A x <= b and Aeq x = beq with the operator splitting of OSQP, Q only needs to be
+// positive semidefinite. The penalty rho is adapted to balance the primal and dual residuals, x0 and y0 warm start the
+// method. The multipliers follow the conventions of ActiveSetQP. When the constraints are infeasible the ray is a
+// certificate as in ActiveSetQP, when the problem is unbounded it is a direction along which the objective decreases.
+// input:
+// the quadratic term (Q), the linear term (c), the inequality matrix (A, nil for none), its right hand side (b), the equality matrix (Aeq, nil for none), its right hand side (beq), initial x (x0, nil for zeros), initial multipliers (y0, nil for zeros), initial penalty (rho), absolute and relative tolerance (es), maximum iterations (iter)
+// output:
+// the estimated x (x), function value (fx), multipliers of the rows of A and Aeq (y), infeasibility or unboundedness certificate (ray), largest primal or dual residual (ea), iterations done (iter)
+func ADMMQP(Q [][]float64, c []float64, A [][]float64, b []float64, Aeq [][]float64, beq []float64, x0 []float64, y0 []float64, rho float64, es float64, maxit int) (x []float64, fx float64, y []float64, ray []float64, ea float64, iter int, err error) {
+    err = checkQP(Q, c, A, b, Aeq, beq)
+    if err != nil {
+        return nil, 0.0, nil, nil, 0.0, 0, err
+    }
+    if es <= 0.0 {
+        return nil, 0.0, nil, nil, 0.0, 0, errors.New("es must be greater than 0")
+    }
+    if rho <= 0.0 {
+        return nil, 0.0, nil, nil, 0.0, 0, errors.New("rho must be greater than 0")
+    }
+    n := len(c)
+    mi := len(A)
+    m := mi + len(Aeq)
+    if (x0 != nil && len(x0) != n) || (y0 != nil && len(y0) != m) {
+        return nil, 0.0, nil, nil, 0.0, 0, errors.New("x0 and y0 must match the number of variables and constraints")
+    }
+    // Constraints l <= C x <= u, the equality rows get a larger penalty
+    C := append(append([][]float64(nil), A...), Aeq...)
+    l := make([]float64, m)
+    u := make([]float64, m)
+    for i := range A {
+        l[i], u[i] = math.Inf(-1), b[i]
+    }
+    for e := range Aeq {
+        l[mi+e], u[mi+e] = beq[e], beq[e]
+    }
+    R := make([]float64, m)
+    const sigma = 1e-6
+    const relax = 1.6
+    var L [][]float64
+    factor := func() error {
+        for i := range R {
+            R[i] = rho
+            if l[i] == u[i] {
+                R[i] = 1e3*rho
+            }
+        }
+        K := make([][]float64, n)
+        for j := range K {
+            K[j] = append([]float64(nil), Q[j]...)
+            K[j][j] += sigma
+        }
+        for i, row := range C {
+            for j := range K {
+                for k := range K {
+                    K[j][k] += R[i]*row[j]*row[k]
+                }
+            }
+        }
+        var ok bool
+        L, ok = cholesky(K)
+        if !ok {
+            return errors.New("Q must be positive semidefinite")
+        }
+        return nil
+    }
+    if err = factor(); err != nil {
+        return nil, 0.0, nil, nil, 0.0, 0, err
+    }
+    CT := func(v []float64) []float64 { // C'v
+        w := make([]float64, n)
+        for i, row := range C {
+            for j := range w {
+                w[j] += row[j]*v[i]
+            }
+        }
+        return w
+    }
+    x = make([]float64, n)
+    if x0 != nil {
+        copy(x, x0)
+    }
+    y = make([]float64, m)
+    if y0 != nil {
+        copy(y, y0)
+    }
+    z := matvec(C, x)
+    for i := range z {
+        z[i] = math.Min(math.Max(z[i], l[i]), u[i])
+    }
+    rhs := make([]float64, m)
+    dy := make([]float64, m)
+    dx := make([]float64, n)
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        for i := range rhs {
+            rhs[i] = R[i]*z[i] - y[i]
+        }
+        r := CT(rhs)
+        for j := range r {
+            r[j] += sigma*x[j] - c[j]
+        }
+        xt := choleskySolve(L, r)
+        zt := matvec(C, xt)
+        for j := range x {
+            xn := relax*xt[j] + (1.0 - relax)*x[j]
+            dx[j] = xn - x[j]
+            x[j] = xn
+        }
+        for i := range z {
+            zr := relax*zt[i] + (1.0 - relax)*z[i]
+            zn := math.Min(math.Max(zr + y[i]/R[i], l[i]), u[i])
+            dy[i] = R[i]*(zr - zn)
+            y[i] += dy[i]
+            z[i] = zn
+        }
+        // Primal and dual residuals
+        Cx := matvec(C, x)
+        Qx := matvec(Q, x)
+        Cy := CT(y)
+        rprim := 0.0
+        for i := range Cx {
+            rprim = math.Max(rprim, math.Abs(Cx[i] - z[i]))
+        }
+        rdual := 0.0
+        for j := range Qx {
+            rdual = math.Max(rdual, math.Abs(Qx[j] + c[j] + Cy[j]))
+        }
+        ea = math.Max(rprim, rdual)
+        scalep := math.Max(norminf(Cx), norminf(z))
+        scaled := math.Max(norminf(Qx), math.Max(norminf(Cy), norminf(c)))
+        if rprim <= es + es*scalep && rdual <= es + es*scaled {
+            break
+        }
+        // Primal infeasibility, dy is a ray with C'dy = 0 and u'max(dy, 0) + l'min(dy, 0) < 0
+        for i := range dy {
+            if math.IsInf(u[i], 1) {
+                dy[i] = math.Min(dy[i], 0.0)
+            }
+            if math.IsInf(l[i], -1) {
+                dy[i] = math.Max(dy[i], 0.0)
+            }
+        }
+        if ndy := norminf(dy); ndy > 0.0 {
+            support := 0.0
+            for i := range dy {
+                if dy[i] > 0.0 {
+                    support += u[i]*dy[i]
+                } else if dy[i] < 0.0 {
+                    support += l[i]*dy[i]
+                }
+            }
+            if norminf(CT(dy)) <= es*ndy && support < -es*ndy {
+                ray = make([]float64, m)
+                for i := range dy {
+                    ray[i] = dy[i]/ndy
+                }
+                return nil, 0.0, nil, ray, ea, iter, errors.New("The constraints are infeasible")
+            }
+        }
+        // Unboundedness, dx is a direction of recession with Q dx = 0 and c'dx < 0
+        if ndx := norminf(dx); ndx > 0.0 && norminf(matvec(Q, dx)) <= es*ndx && dot(c, dx) < -es*ndx {
+            recession := true
+            for i, v := range matvec(C, dx) {
+                recession = recession && (math.IsInf(u[i], 1) || v <= es*ndx) && (math.IsInf(l[i], -1) || v >= -es*ndx)
+            }
+            if recession {
+                ray = make([]float64, n)
+                for j := range dx {
+                    ray[j] = dx[j]/ndx
+                }
+                return nil, 0.0, nil, ray, ea, iter, errors.New("The problem is unbounded")
+            }
+        }
+        // Penalty update balancing the scaled residuals
+        if iter%25 == 24 {
+            ratio := math.Sqrt((rprim/math.Max(scalep, 1e-30))/(rdual/math.Max(scaled, 1e-30) + 1e-30))
+            newrho := math.Min(math.Max(rho*ratio, 1e-6), 1e6)
+            if newrho > 5.0*rho || newrho < 0.2*rho {
+                rho = newrho
+                if err = factor(); err != nil {
+                    return nil, 0.0, nil, nil, 0.0, iter, err
+                }
+            }
+        }
+    }
+    return x, quadratic(Q, c, x), y, nil, ea, iter, nil
+}
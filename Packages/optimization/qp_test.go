@@ -0,0 +1,168 @@
+package optimization
+
+import (
+    "testing"
+    "fmt"
+    "math"
+)
+
+// TestDualActiveSet calls optimization.dualActiveSet with min x0^2 + x1^2 - 2 x0 - 5 x1 subject to
+// x0 + x1 = 2, x0 >= 0 and x1 <= 1.5, checking for the minimum and the multipliers.
+func TestDualActiveSet(t *testing.T) {
+    G := [][]float64{{2.0, 0.0}, {0.0, 2.0}}
+    a := []float64{-2.0, -5.0}
+    C := [][]float64{{1.0, 1.0}, {1.0, 0.0}, {0.0, -1.0}}
+    b := []float64{2.0, 0.0, -1.5}
+    // the minimum is at (0.5, 1.5) with u = (-1, 0, 1)
+    x, u, active, ray, err := dualActiveSet(G, a, C, b, 1, nil)
+    msg := fmt.Sprintf("%v, %v, %v, %v", x, u, active, ray)
+    want := "[0.5 1.5], [-1 0 1], [0 2], []"
+    if err != nil || math.Abs(x[0] - 0.5) > 1e-12 || math.Abs(x[1] - 1.5) > 1e-12 || math.Abs(u[0] + 1.0) > 1e-12 || u[1] != 0.0 || math.Abs(u[2] - 1.0) > 1e-12 || len(active) != 2 || ray != nil {
+        t.Fatalf(`dualActiveSet(G, a, C, b, 1, nil) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+    // warm starting from the optimal active set gives the same result
+    xw, uw, _, _, err := dualActiveSet(G, a, C, b, 1, []int{2})
+    if err != nil || math.Abs(xw[0] - x[0]) > 1e-12 || math.Abs(xw[1] - x[1]) > 1e-12 || math.Abs(uw[2] - u[2]) > 1e-12 {
+        t.Fatalf(`dualActiveSet(G, a, C, b, 1, [2]) = %v, %v, %v, want match for %v, %v, nil`, xw, uw, err, x, u)
+    }
+}
+
+// TestDualActiveSetInfeasible calls optimization.dualActiveSet with x0 >= 1 and -x0 >= 0,
+// checking for an error and a ray proving infeasibility.
+func TestDualActiveSetInfeasible(t *testing.T) {
+    G := [][]float64{{1.0, 0.0}, {0.0, 1.0}}
+    a := []float64{0.0, 0.0}
+    C := [][]float64{{1.0, 0.0}, {-1.0, 0.0}}
+    b := []float64{1.0, 0.0}
+    x, _, _, ray, err := dualActiveSet(G, a, C, b, 0, nil)
+    if err == nil || x != nil || len(ray) != 2 || ray[0] < 0.0 || ray[1] < 0.0 || math.Abs(ray[0]*C[0][0] + ray[1]*C[1][0]) > 1e-12 || ray[0]*b[0] + ray[1]*b[1] <= 0.0 {
+        t.Fatalf(`dualActiveSet(G, a, C, b, 0, nil) = %v, %v, %v, want nil, ray, error`, x, ray, err)
+    }
+}
+
+// TestActiveSetQP calls optimization.ActiveSetQP with min x0^2 + x1^2 - 2 x0 - 5 x1 subject to -x0 <= 0, x1 <= 1.5 and
+// x0 + x1 = 2, and again warm started from the returned active set, checking for the minimum and the multipliers.
+func TestActiveSetQP(t *testing.T) {
+    Q := [][]float64{{2.0, 0.0}, {0.0, 2.0}}
+    c := []float64{-2.0, -5.0}
+    A := [][]float64{{-1.0, 0.0}, {0.0, 1.0}}
+    b := []float64{0.0, 1.5}
+    Aeq := [][]float64{{1.0, 1.0}}
+    beq := []float64{2.0}
+    x, fx, y, active, ray, err := ActiveSetQP(Q, c, A, b, Aeq, beq, nil)
+    msg := fmt.Sprintf("%v, %f, %v, %v, %v", x, fx, y, active, ray)
+    want := "[0.5 1.5], -6, [0 1 1], [1], []"
+    if err != nil || math.Abs(x[0] - 0.5) > 1e-12 || math.Abs(x[1] - 1.5) > 1e-12 || math.Abs(fx + 6.0) > 1e-12 || y[0] != 0.0 || math.Abs(y[1] - 1.0) > 1e-12 || math.Abs(y[2] - 1.0) > 1e-12 || len(active) != 1 || active[0] != 1 || ray != nil {
+        t.Fatalf(`ActiveSetQP(Q, c, A, b, Aeq, beq, nil) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+    xw, fxw, _, activew, _, err := ActiveSetQP(Q, c, A, b, Aeq, beq, active)
+    if err != nil || math.Abs(xw[0] - x[0]) > 1e-12 || math.Abs(xw[1] - x[1]) > 1e-12 || math.Abs(fxw - fx) > 1e-12 || len(activew) != 1 {
+        t.Fatalf(`ActiveSetQP(Q, c, A, b, Aeq, beq, [1]) = %v, %f, %v, %v, want match for %v, %f, [1], nil`, xw, fxw, activew, err, x, fx)
+    }
+}
+
+// TestActiveSetQPInfeasible calls optimization.ActiveSetQP with x0 <= -1 and -x0 <= 0,
+// checking for an error and a certificate of infeasibility.
+func TestActiveSetQPInfeasible(t *testing.T) {
+    Q := [][]float64{{1.0, 0.0}, {0.0, 1.0}}
+    c := []float64{0.0, 0.0}
+    A := [][]float64{{1.0, 0.0}, {-1.0, 0.0}}
+    b := []float64{-1.0, 0.0}
+    x, fx, y, active, ray, err := ActiveSetQP(Q, c, A, b, nil, nil, nil)
+    if err == nil || x != nil || fx != 0.0 || y != nil || active != nil || len(ray) != 2 || ray[0] < 0.0 || ray[1] < 0.0 || math.Abs(ray[0] - ray[1]) > 1e-12 || ray[0]*b[0] + ray[1]*b[1] >= 0.0 {
+        t.Fatalf(`ActiveSetQP(Q, c, A, b, nil, nil, nil) = %v, %f, %v, %v, %v, %v, want nil, 0, nil, nil, ray, error`, x, fx, y, active, ray, err)
+    }
+}
+
+// TestActiveSetQPSingular calls optimization.ActiveSetQP with a singular quadratic term,
+// checking for an error.
+func TestActiveSetQPSingular(t *testing.T) {
+    Q := [][]float64{{1.0, 0.0}, {0.0, 0.0}}
+    x, fx, y, active, ray, err := ActiveSetQP(Q, []float64{0.0, -1.0}, nil, nil, nil, nil, nil)
+    if err == nil || x != nil || fx != 0.0 || y != nil || active != nil || ray != nil {
+        t.Fatalf(`ActiveSetQP(Q, [0, -1], nil, nil, nil, nil, nil) = %v, %f, %v, %v, %v, %v, want nil, 0, nil, nil, nil, error`, x, fx, y, active, ray, err)
+    }
+}
+
+// TestADMMQP calls optimization.ADMMQP with min x0^2 + x1^2 - 2 x0 - 5 x1 subject to -x0 <= 0, x1 <= 1.5 and
+// x0 + x1 = 2, and again warm started from the solution, checking for the minimum and the multipliers.
+func TestADMMQP(t *testing.T) {
+    Q := [][]float64{{2.0, 0.0}, {0.0, 2.0}}
+    c := []float64{-2.0, -5.0}
+    A := [][]float64{{-1.0, 0.0}, {0.0, 1.0}}
+    b := []float64{0.0, 1.5}
+    Aeq := [][]float64{{1.0, 1.0}}
+    beq := []float64{2.0}
+    es := 1e-8
+    maxit := 2000
+    x, fx, y, ray, ea, iter, err := ADMMQP(Q, c, A, b, Aeq, beq, nil, nil, 0.1, es, maxit)
+    msg := fmt.Sprintf("%v, %f, %v, %v, %g, %d", x, fx, y, ray, ea, iter)
+    want := fmt.Sprintf("[0.5 1.5], -6, [0 1 1], [], %g, %d < %d", es, iter, maxit)
+    if err != nil || math.Abs(x[0] - 0.5) > 1e-6 || math.Abs(x[1] - 1.5) > 1e-6 || math.Abs(fx + 6.0) > 1e-6 || math.Abs(y[0]) > 1e-6 || math.Abs(y[1] - 1.0) > 1e-6 || math.Abs(y[2] - 1.0) > 1e-6 || ray != nil || iter >= maxit {
+        t.Fatalf(`ADMMQP(Q, c, A, b, Aeq, beq, nil, nil, 0.1, 1e-8, 2000) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+    _, _, _, _, _, iterw, err := ADMMQP(Q, c, A, b, Aeq, beq, x, y, 0.1, es, maxit)
+    if err != nil || iterw > 1 {
+        t.Fatalf(`ADMMQP(Q, c, A, b, Aeq, beq, x, y, 0.1, 1e-8, 2000) = %d, %v, want <= 1, nil`, iterw, err)
+    }
+}
+
+// TestADMMQPSemidefinite calls optimization.ADMMQP with min x0^2/2 - x1 subject to x1 <= 2 and x1 - x0 <= 1,
+// checking for the minimum -1.5 at (1, 2).
+func TestADMMQPSemidefinite(t *testing.T) {
+    Q := [][]float64{{1.0, 0.0}, {0.0, 0.0}}
+    c := []float64{0.0, -1.0}
+    A := [][]float64{{0.0, 1.0}, {-1.0, 1.0}}
+    b := []float64{2.0, 1.0}
+    x, fx, y, ray, ea, iter, err := ADMMQP(Q, c, A, b, nil, nil, nil, nil, 0.1, 1e-8, 5000)
+    msg := fmt.Sprintf("%v, %f, %v, %v, %g, %d", x, fx, y, ray, ea, iter)
+    want := "[1 2], -1.5, [0 1], []"
+    if err != nil || math.Abs(x[0] - 1.0) > 1e-6 || math.Abs(x[1] - 2.0) > 1e-6 || math.Abs(fx + 1.5) > 1e-6 || math.Abs(y[0]) > 1e-6 || math.Abs(y[1] - 1.0) > 1e-6 {
+        t.Fatalf(`ADMMQP(Q, c, A, b, nil, nil, nil, nil, 0.1, 1e-8, 5000) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestADMMQPPortfolio calls optimization.ADMMQP and optimization.ActiveSetQP with a minimum variance portfolio with
+// a required return and no short selling, checking that both methods agree.
+func TestADMMQPPortfolio(t *testing.T) {
+    Q := [][]float64{{0.10, 0.02, 0.04}, {0.02, 0.08, 0.01}, {0.04, 0.01, 0.20}}
+    c := []float64{0.0, 0.0, 0.0}
+    A := [][]float64{{-0.08, -0.05, -0.15}, {-1.0, 0.0, 0.0}, {0.0, -1.0, 0.0}, {0.0, 0.0, -1.0}}
+    b := []float64{-0.09, 0.0, 0.0, 0.0}
+    Aeq := [][]float64{{1.0, 1.0, 1.0}}
+    beq := []float64{1.0}
+    xa, fxa, _, _, _, err := ActiveSetQP(Q, c, A, b, Aeq, beq, nil)
+    if err != nil {
+        t.Fatalf(`ActiveSetQP(Q, c, A, b, Aeq, beq, nil) = %v, %f, %v, want nil error`, xa, fxa, err)
+    }
+    x, fx, _, _, ea, iter, err := ADMMQP(Q, c, A, b, Aeq, beq, nil, nil, 0.1, 1e-9, 5000)
+    if err != nil || math.Abs(x[0] - xa[0]) > 1e-6 || math.Abs(x[1] - xa[1]) > 1e-6 || math.Abs(x[2] - xa[2]) > 1e-6 || math.Abs(fx - fxa) > 1e-8 {
+        t.Fatalf(`ADMMQP(Q, c, A, b, Aeq, beq, nil, nil, 0.1, 1e-9, 5000) = %v, %f, %g, %d, %v, want match for %v, %f, nil`, x, fx, ea, iter, err, xa, fxa)
+    }
+}
+
+// TestADMMQPInfeasible calls optimization.ADMMQP with x0 <= -1 and -x0 <= 0,
+// checking for an error and a certificate of infeasibility.
+func TestADMMQPInfeasible(t *testing.T) {
+    Q := [][]float64{{1.0, 0.0}, {0.0, 1.0}}
+    c := []float64{0.0, 0.0}
+    A := [][]float64{{1.0, 0.0}, {-1.0, 0.0}}
+    b := []float64{-1.0, 0.0}
+    x, fx, y, ray, ea, iter, err := ADMMQP(Q, c, A, b, nil, nil, nil, nil, 0.1, 1e-6, 5000)
+    if err == nil || x != nil || fx != 0.0 || y != nil || len(ray) != 2 || ray[0] < 0.0 || ray[1] < 0.0 || math.Abs(ray[0] - ray[1]) > 1e-5 || ray[0]*b[0] + ray[1]*b[1] >= 0.0 {
+        t.Fatalf(`ADMMQP(Q, c, A, b, nil, nil, nil, nil, 0.1, 1e-6, 5000) = %v, %f, %v, %v, %g, %d, %v, want nil, 0, nil, ray, ea, iter, error`, x, fx, y, ray, ea, iter, err)
+    }
+}
+
+// TestADMMQPUnbounded calls optimization.ADMMQP with min x0^2/2 - x1 subject to x0 <= 1,
+// checking for an error and a direction of unbounded descent.
+func TestADMMQPUnbounded(t *testing.T) {
+    Q := [][]float64{{1.0, 0.0}, {0.0, 0.0}}
+    c := []float64{0.0, -1.0}
+    A := [][]float64{{1.0, 0.0}}
+    b := []float64{1.0}
+    x, fx, y, ray, ea, iter, err := ADMMQP(Q, c, A, b, nil, nil, nil, nil, 0.1, 1e-6, 5000)
+    if err == nil || x != nil || fx != 0.0 || y != nil || len(ray) != 2 || math.Abs(ray[0]) > 1e-5 || ray[1] <= 0.0 {
+        t.Fatalf(`ADMMQP(Q, c, A, b, nil, nil, nil, nil, 0.1, 1e-6, 5000) = %v, %f, %v, %v, %g, %d, %v, want nil, 0, nil, ray, ea, iter, error`, x, fx, y, ray, ea, iter, err)
+    }
+}
@@ -0,0 +1,209 @@
+package optimization
+
+import (
+    "errors"
+    "math"
+)
+
+// defaultLineSearch is used by the multivariate minimizers when no line search is given
+func defaultLineSearch() LineSearch {
+    return Backtracking(1e-4, 0.5)
+}
+
+// checkMultivariate validates the arguments shared by the gradient based minimizers and fills in the defaults
+func checkMultivariate(f func([]float64) float64, grad func([]float64) []float64, x0 []float64, ls LineSearch, es float64) (func([]float64) []float64, LineSearch, error) {
+    if es < 0.0 {
+        return nil, nil, errors.New("es must be greater than 0")
+    }
+    if len(x0) == 0 {
+        return nil, nil, errors.New("x0 must not be empty")
+    }
+    if grad == nil {
+        grad = fdGradient(f)
+    }
+    if ls == nil {
+        ls = defaultLineSearch()
+    }
+    return grad, ls, nil
+}
+
+// firstStep returns the initial step length for the first iteration, where no curvature information is known
+func firstStep(g []float64) float64 {
+    return math.Min(1.0, 1.0/norminf(g))
+}
+
+// BFGS (Broyden-Fletcher-Goldfarb-Shanno quasi-Newton method)
+// Keeps a dense approximation of the inverse Hessian, the update is skipped when the curvature condition s'y > 0 fails
+// input:
+// the function to find the minimum for (f), the gradient of f (grad, nil uses central differences), initial guess (x0), line search (ls, nil uses the default), gradient norm tolerance (es), maximum iterations (iter)
+// output:
+// the estimated x (x), function value (fx), largest absolute gradient entry (ea), iterations done (iter)
+func BFGS(f func([]float64) float64, grad func([]float64) []float64, x0 []float64, ls LineSearch, es float64, maxit int) (x []float64, fx float64, ea float64, iter int, err error) {
+    grad, ls, err = checkMultivariate(f, grad, x0, ls, es)
+    if err != nil {
+        return nil, 0.0, 0.0, 0, err
+    }
+    n := len(x0)
+    H := make([][]float64, n)
+    identity := func() {
+        for i := range H {
+            H[i] = make([]float64, n)
+            H[i][i] = 1.0
+        }
+    }
+    identity()
+    x = append([]float64(nil), x0...)
+    fx = f(x)
+    g := grad(x)
+    ea = norminf(g)
+    d := make([]float64, n)
+    hy := make([]float64, n)
+    s := make([]float64, n)
+    y := make([]float64, n)
+    first := true
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        if ea <= es {
+            break
+        }
+        for i := range d {
+            d[i] = -dot(H[i], g)
+        }
+        slope := dot(g, d)
+        if slope >= 0.0 { // the approximation lost positive definiteness, restart from steepest descent
+            identity()
+            first = true
+            for i := range d {
+                d[i] = -g[i]
+            }
+            slope = dot(g, d)
+        }
+        alpha0 := 1.0
+        if first {
+            alpha0 = firstStep(g)
+        }
+        phi, dphi, last := lineFunctions(f, grad, x, d)
+        alpha, fnew, lserr := ls(phi, dphi, fx, slope, alpha0)
+        if lserr != nil {
+            return x, fx, ea, iter, lserr
+        }
+        xnew := axpy(x, alpha, d)
+        gnew := last(alpha)
+        for i := range s {
+            s[i] = xnew[i] - x[i]
+            y[i] = gnew[i] - g[i]
+        }
+        sy := dot(s, y)
+        if sy > 1e-12*math.Sqrt(dot(s, s)*dot(y, y)) {
+            if first { // scale the initial approximation to the curvature along s
+                scale := sy/dot(y, y)
+                for i := range H {
+                    H[i][i] = scale
+                }
+            }
+            rho := 1.0/sy
+            for i := range hy {
+                hy[i] = dot(H[i], y)
+            }
+            yhy := dot(y, hy)
+            for i := 0; i < n; i++ {
+                for j := 0; j < n; j++ {
+                    H[i][j] += -rho*(hy[i]*s[j] + s[i]*hy[j]) + (rho*rho*yhy + rho)*s[i]*s[j]
+                }
+            }
+            first = false
+        }
+        x, fx, g = xnew, fnew, gnew
+        ea = norminf(g)
+    }
+    return x, fx, ea, iter, nil
+}
+
+// LBFGS (Limited-memory BFGS)
+// Stores only the last m pairs of steps and gradient changes and applies the inverse Hessian with the two-loop recursion
+// input:
+// the function to find the minimum for (f), the gradient of f (grad, nil uses central differences), initial guess (x0), number of stored pairs (m), line search (ls, nil uses the default), gradient norm tolerance (es), maximum iterations (iter)
+// output:
+// the estimated x (x), function value (fx), largest absolute gradient entry (ea), iterations done (iter)
+func LBFGS(f func([]float64) float64, grad func([]float64) []float64, x0 []float64, m int, ls LineSearch, es float64, maxit int) (x []float64, fx float64, ea float64, iter int, err error) {
+    grad, ls, err = checkMultivariate(f, grad, x0, ls, es)
+    if err != nil {
+        return nil, 0.0, 0.0, 0, err
+    }
+    if m <= 0 {
+        return nil, 0.0, 0.0, 0, errors.New("m must be greater than 0")
+    }
+    n := len(x0)
+    var ss, ys [][]float64
+    var rhos []float64
+    x = append([]float64(nil), x0...)
+    fx = f(x)
+    g := grad(x)
+    ea = norminf(g)
+    d := make([]float64, n)
+    a := make([]float64, m)
+    iter = 0
+    for ; iter < maxit; iter ++ {
+        if ea <= es {
+            break
+        }
+        for i := range d { // two-loop recursion for d = -H g
+            d[i] = -g[i]
+        }
+        k := len(ss)
+        for j := k - 1; j >= 0; j-- {
+            a[j] = rhos[j]*dot(ss[j], d)
+            for i := range d {
+                d[i] -= a[j]*ys[j][i]
+            }
+        }
+        if k > 0 {
+            gamma := dot(ss[k-1], ys[k-1])/dot(ys[k-1], ys[k-1])
+            for i := range d {
+                d[i] *= gamma
+            }
+        }
+        for j := 0; j < k; j++ {
+            b := rhos[j]*dot(ys[j], d)
+            for i := range d {
+                d[i] += (a[j] - b)*ss[j][i]
+            }
+        }
+        slope := dot(g, d)
+        if slope >= 0.0 { // discard the history and restart from steepest descent
+            ss, ys, rhos = nil, nil, nil
+            for i := range d {
+                d[i] = -g[i]
+            }
+            slope = dot(g, d)
+        }
+        alpha0 := 1.0
+        if len(ss) == 0 {
+            alpha0 = firstStep(g)
+        }
+        phi, dphi, last := lineFunctions(f, grad, x, d)
+        alpha, fnew, lserr := ls(phi, dphi, fx, slope, alpha0)
+        if lserr != nil {
+            return x, fx, ea, iter, lserr
+        }
+        xnew := axpy(x, alpha, d)
+        gnew := last(alpha)
+        s := make([]float64, n)
+        y := make([]float64, n)
+        for i := range s {
+            s[i] = xnew[i] - x[i]
+            y[i] = gnew[i] - g[i]
+        }
+        if sy := dot(s, y); sy > 1e-12*math.Sqrt(dot(s, s)*dot(y, y)) {
+            if len(ss) == m {
+                ss, ys, rhos = ss[1:], ys[1:], rhos[1:]
+            }
+            ss = append(ss, s)
+            ys = append(ys, y)
+            rhos = append(rhos, 1.0/sy)
+        }
+        x, fx, g = xnew, fnew, gnew
+        ea = norminf(g)
+    }
+    return x, fx, ea, iter, nil
+}
@@ -0,0 +1,123 @@
+package optimization
+
+import (
+    "testing"
+    "errors"
+    "fmt"
+    "math"
+)
+
+// TestBFGS calls optimization.BFGS with the Rosenbrock function, its gradient, an initial guess, the default line search,
+// error limit and max iterations, checking for a valid return value.
+func TestBFGS(t *testing.T) {
+    f := func(x []float64) float64 {
+        return (1.0 - x[0])*(1.0 - x[0]) + 100.0*(x[1] - x[0]*x[0])*(x[1] - x[0]*x[0])
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{-2.0*(1.0 - x[0]) - 400.0*x[0]*(x[1] - x[0]*x[0]), 200.0*(x[1] - x[0]*x[0])}
+    }
+    x0 := []float64{-1.2, 1.0}
+    es := 1e-8
+    maxit := 200
+    xwant := []float64{1.0, 1.0}
+    fxwant := 0.0
+    x, fx, ea, iter, err := BFGS(f, grad, x0, nil, es, maxit)
+    msg := fmt.Sprintf("%v, %g, %g, %d", x, fx, ea, iter)
+    want := fmt.Sprintf("%v, %g, %g, %d < %d", xwant, fxwant, es, iter, maxit)
+    if err != nil || math.Abs(x[0] - xwant[0]) > 1e-6 || math.Abs(x[1] - xwant[1]) > 1e-6 || ea > es || iter >= maxit {
+        t.Fatalf(`BFGS(rosenbrock, grad, [-1.2, 1], nil, 1e-8, 200) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestBFGSFiniteDifference calls optimization.BFGS without a gradient, checking that the finite difference
+// fallback reaches the minimum.
+func TestBFGSFiniteDifference(t *testing.T) {
+    f := func(x []float64) float64 { // extended Rosenbrock function, the minimum is at x = 1
+        s := 0.0
+        for i := 0; i + 1 < len(x); i += 2 {
+            s += (1.0 - x[i])*(1.0 - x[i]) + 100.0*(x[i+1] - x[i]*x[i])*(x[i+1] - x[i]*x[i])
+        }
+        return s
+    }
+    x, fx, ea, iter, err := BFGS(f, nil, []float64{-1.2, 1.0, -1.2, 1.0}, nil, 1e-5, 500)
+    for i := range x {
+        if err != nil || math.Abs(x[i] - 1.0) > 1e-4 || ea > 1e-5 {
+            t.Fatalf(`BFGS(rosenbrock, nil, x0, nil, 1e-5, 500) = %v, %g, %g, %d, %v, want all 1`, x, fx, ea, iter, err)
+        }
+    }
+}
+
+// TestLBFGS calls optimization.LBFGS with the extended Rosenbrock function in 1000 dimensions, checking
+// for a valid return value.
+func TestLBFGS(t *testing.T) {
+    f := func(x []float64) float64 { // extended Rosenbrock function, the minimum is at x = 1
+        s := 0.0
+        for i := 0; i + 1 < len(x); i += 2 {
+            s += (1.0 - x[i])*(1.0 - x[i]) + 100.0*(x[i+1] - x[i]*x[i])*(x[i+1] - x[i]*x[i])
+        }
+        return s
+    }
+    grad := func(x []float64) []float64 {
+        g := make([]float64, len(x))
+        for i := 0; i + 1 < len(x); i += 2 {
+            g[i] = -2.0*(1.0 - x[i]) - 400.0*x[i]*(x[i+1] - x[i]*x[i])
+            g[i+1] = 200.0*(x[i+1] - x[i]*x[i])
+        }
+        return g
+    }
+    n := 1000
+    es := 1e-6
+    maxit := 1000
+    x0 := make([]float64, n)
+    for i := range x0 {
+        x0[i] = 1.0
+        if i % 2 == 0 {
+            x0[i] = -1.2
+        }
+    }
+    x, fx, ea, iter, err := LBFGS(f, grad, x0, 7, nil, es, maxit)
+    if err != nil || fx > 1e-10 || ea > es || iter >= maxit {
+        t.Fatalf(`LBFGS(rosenbrock, grad, x0, 7, nil, 1e-6, 1000) = %g, %g, %d, %v, want 0, < %g, < %d, nil`, fx, ea, iter, err, es, maxit)
+    }
+    for i := range x {
+        if math.Abs(x[i] - 1.0) > 1e-5 {
+            t.Fatalf(`LBFGS(rosenbrock, grad, x0, 7, nil, 1e-6, 1000) x[%d] = %f, want 1`, i, x[i])
+        }
+    }
+}
+
+// TestLBFGSMemory calls optimization.LBFGS with zero stored pairs, checking for an error.
+func TestLBFGSMemory(t *testing.T) {
+    f := func(x []float64) float64 {
+        return (1.0 - x[0])*(1.0 - x[0]) + 100.0*(x[1] - x[0]*x[0])*(x[1] - x[0]*x[0])
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{-2.0*(1.0 - x[0]) - 400.0*x[0]*(x[1] - x[0]*x[0]), 200.0*(x[1] - x[0]*x[0])}
+    }
+    x, fx, ea, iter, err := LBFGS(f, grad, []float64{-1.2, 1.0}, 0, nil, 1e-6, 100)
+    if x != nil || fx != 0.0 || ea != 0.0 || iter != 0 || err == nil {
+        t.Fatalf(`LBFGS(rosenbrock, grad, x0, 0, nil, 1e-6, 100) = %v, %f, %f, %d, %v, want nil, 0, 0, 0, error`, x, fx, ea, iter, err)
+    }
+}
+
+// TestBFGSLineSearchFailure calls optimization.BFGS and optimization.LBFGS with a line search that always fails,
+// checking that the failure is returned as an error.
+func TestBFGSLineSearchFailure(t *testing.T) {
+    f := func(x []float64) float64 {
+        return (1.0 - x[0])*(1.0 - x[0]) + 100.0*(x[1] - x[0]*x[0])*(x[1] - x[0]*x[0])
+    }
+    grad := func(x []float64) []float64 {
+        return []float64{-2.0*(1.0 - x[0]) - 400.0*x[0]*(x[1] - x[0]*x[0]), 200.0*(x[1] - x[0]*x[0])}
+    }
+    fail := func(phi func(float64) float64, dphi func(float64) float64, phi0 float64, dphi0 float64, alpha0 float64) (float64, float64, error) {
+        return 0.0, 0.0, errors.New("no step")
+    }
+    x, fx, ea, iter, err := BFGS(f, grad, []float64{-1.2, 1.0}, fail, 1e-8, 100)
+    if err == nil {
+        t.Fatalf(`BFGS(rosenbrock, grad, x0, fail, 1e-8, 100) = %v, %g, %g, %d, nil, want error`, x, fx, ea, iter)
+    }
+    x, fx, ea, iter, err = LBFGS(f, grad, []float64{-1.2, 1.0}, 5, fail, 1e-8, 100)
+    if err == nil {
+        t.Fatalf(`LBFGS(rosenbrock, grad, x0, 5, fail, 1e-8, 100) = %v, %g, %g, %d, nil, want error`, x, fx, ea, iter)
+    }
+}
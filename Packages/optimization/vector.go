@@ -0,0 +1,32 @@
+package optimization
+
+import (
+    "math"
+)
+
+// dot returns the inner product of a and b
+func dot(a []float64, b []float64) float64 {
+    s := 0.0
+    for i := range a {
+        s += a[i]*b[i]
+    }
+    return s
+}
+
+// norminf returns the largest absolute entry of a
+func norminf(a []float64) float64 {
+    m := 0.0
+    for _, v := range a {
+        m = math.Max(m, math.Abs(v))
+    }
+    return m
+}
+
+// axpy returns x + alpha*d as a new slice
+func axpy(x []float64, alpha float64, d []float64) []float64 {
+    y := make([]float64, len(x))
+    for i := range x {
+        y[i] = x[i] + alpha*d[i]
+    }
+    return y
+}
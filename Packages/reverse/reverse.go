@@ -0,0 +1,256 @@
+package reverse
+
+import (
+    "errors"
+    "math"
+)
+
+// node is one recorded operation on the tape, it stores the indices of up to two parents (-1 if unused)
+// together with the local partial derivatives of the operation with respect to them
+type node struct {
+    val     float64
+    parents [2]int
+    weights [2]float64
+}
+
+// Tape records the operations performed on variables so the gradient can be computed by a single reverse sweep
+type Tape struct {
+    nodes  []node
+    inputs []int
+}
+
+// Var is a value recorded on a tape
+type Var struct {
+    tape  *Tape
+    index int
+}
+
+// NewTape returns an empty tape
+func NewTape() *Tape {
+    return &Tape{}
+}
+
+// push records a node on the tape and returns it as a variable
+func (t *Tape) push(val float64, p0 int, w0 float64, p1 int, w1 float64) Var {
+    t.nodes = append(t.nodes, node{val, [2]int{p0, p1}, [2]float64{w0, w1}})
+    return Var{t, len(t.nodes) - 1}
+}
+
+// Var records an independent variable with value x on the tape, the gradient is taken with respect to these
+func (t *Tape) Var(x float64) Var {
+    v := t.push(x, -1, 0.0, -1, 0.0)
+    t.inputs = append(t.inputs, v.index)
+    return v
+}
+
+// Const records the constant c on the tape
+func (t *Tape) Const(c float64) Var {
+    return t.push(c, -1, 0.0, -1, 0.0)
+}
+
+// Len returns the number of recorded operations
+func (t *Tape) Len() int {
+    return len(t.nodes)
+}
+
+// Gradient
+// Performs the reverse sweep from y
+// input:
+// the output variable (y)
+// output:
+// the derivatives of y with respect to every variable created by Tape.Var in creation order (grad)
+func (t *Tape) Gradient(y Var) (grad []float64, err error) {
+    if y.tape != t {
+        return nil, errors.New("y is not recorded on this tape")
+    }
+    adj := make([]float64, y.index + 1)
+    adj[y.index] = 1.0
+    for i := y.index; i >= 0; i-- {
+        if adj[i] == 0.0 {
+            continue
+        }
+        n := t.nodes[i]
+        for k := 0; k < 2; k++ {
+            if n.parents[k] >= 0 {
+                adj[n.parents[k]] += n.weights[k]*adj[i]
+            }
+        }
+    }
+    grad = make([]float64, len(t.inputs))
+    for k, i := range t.inputs {
+        if i <= y.index {
+            grad[k] = adj[i]
+        }
+    }
+    return grad, nil
+}
+
+// Value returns the value of x
+func (x Var) Value() float64 {
+    return x.tape.nodes[x.index].val
+}
+
+// unary records a scalar function with value g and derivative dg applied to x
+func (x Var) unary(g float64, dg float64) Var {
+    return x.tape.push(g, x.index, dg, -1, 0.0)
+}
+
+// binary records an operation on x and y with value g and partial derivatives dx and dy, mixing tapes is a
+// programming error that would silently lose the derivatives recorded on the other tape, so it panics
+func (x Var) binary(y Var, g float64, dx float64, dy float64) Var {
+    if x.tape != y.tape {
+        panic("reverse: operands are recorded on different tapes")
+    }
+    return x.tape.push(g, x.index, dx, y.index, dy)
+}
+
+// Add returns x + y
+func (x Var) Add(y Var) Var {
+    return x.binary(y, x.Value() + y.Value(), 1.0, 1.0)
+}
+
+// Sub returns x - y
+func (x Var) Sub(y Var) Var {
+    return x.binary(y, x.Value() - y.Value(), 1.0, -1.0)
+}
+
+// Mul returns x * y
+func (x Var) Mul(y Var) Var {
+    a, b := x.Value(), y.Value()
+    return x.binary(y, a*b, b, a)
+}
+
+// Div returns x / y
+func (x Var) Div(y Var) Var {
+    a, b := x.Value(), y.Value()
+    return x.binary(y, a/b, 1.0/b, -a/(b*b))
+}
+
+// Neg returns -x
+func (x Var) Neg() Var {
+    return x.unary(-x.Value(), -1.0)
+}
+
+// Scale returns c * x
+func (x Var) Scale(c float64) Var {
+    return x.unary(c*x.Value(), c)
+}
+
+// Shift returns x + c
+func (x Var) Shift(c float64) Var {
+    return x.unary(x.Value() + c, 1.0)
+}
+
+// Sqrt returns the square root of x
+func Sqrt(x Var) Var {
+    s := math.Sqrt(x.Value())
+    return x.unary(s, 0.5/s)
+}
+
+// Pow returns x**p for a real exponent p, the exponents 0, 1 and 2 are exact also at x = 0
+func Pow(x Var, p float64) Var {
+    switch p {
+    case 0.0:
+        return x.tape.Const(1.0)
+    case 1.0:
+        return x
+    case 2.0:
+        return x.Mul(x)
+    }
+    a := x.Value()
+    return x.unary(math.Pow(a, p), p*math.Pow(a, p - 1.0))
+}
+
+// Exp returns e**x
+func Exp(x Var) Var {
+    e := math.Exp(x.Value())
+    return x.unary(e, e)
+}
+
+// Log returns the natural logarithm of x
+func Log(x Var) Var {
+    a := x.Value()
+    return x.unary(math.Log(a), 1.0/a)
+}
+
+// Sin returns the sine of x
+func Sin(x Var) Var {
+    s, c := math.Sincos(x.Value())
+    return x.unary(s, c)
+}
+
+// Cos returns the cosine of x
+func Cos(x Var) Var {
+    s, c := math.Sincos(x.Value())
+    return x.unary(c, -s)
+}
+
+// Tan returns the tangent of x
+func Tan(x Var) Var {
+    t := math.Tan(x.Value())
+    return x.unary(t, 1.0 + t*t)
+}
+
+// Atan returns the arctangent of x
+func Atan(x Var) Var {
+    a := x.Value()
+    return x.unary(math.Atan(a), 1.0/(1.0 + a*a))
+}
+
+// Tanh returns the hyperbolic tangent of x
+func Tanh(x Var) Var {
+    t := math.Tanh(x.Value())
+    return x.unary(t, 1.0 - t*t)
+}
+
+// Abs returns the absolute value of x, the derivative at 0 is taken as 0
+func Abs(x Var) Var {
+    a := x.Value()
+    switch {
+    case a < 0.0:
+        return x.unary(-a, -1.0)
+    case a > 0.0:
+        return x.unary(a, 1.0)
+    }
+    return x.unary(0.0, 0.0)
+}
+
+// Sum returns the sum of xs, xs must not be empty
+func Sum(xs []Var) Var {
+    s := xs[0]
+    for _, x := range xs[1:] {
+        s = s.Add(x)
+    }
+    return s
+}
+
+// Differentiate
+// Builds the objective and its gradient for gradient based minimizers such as optimization.GradientDescent
+// input:
+// the function written in tape variables (f), it receives one variable per coordinate and must record its result on the same tape
+// output:
+// the function (fx), the gradient obtained by reverse-mode differentiation (grad), which panics like the mixing of
+// tapes in an operation when the result of f is recorded on another tape
+func Differentiate(f func(t *Tape, x []Var) Var) (fx func([]float64) float64, grad func([]float64) []float64) {
+    record := func(x []float64) (*Tape, Var) {
+        t := NewTape()
+        xs := make([]Var, len(x))
+        for i := range x {
+            xs[i] = t.Var(x[i])
+        }
+        return t, f(t, xs)
+    }
+    fx = func(x []float64) float64 {
+        _, y := record(x)
+        return y.Value()
+    }
+    grad = func(x []float64) []float64 {
+        t, y := record(x)
+        g, err := t.Gradient(y)
+        if err != nil {
+            panic("reverse: the result of f is recorded on another tape")
+        }
+        return g
+    }
+    return fx, grad
+}
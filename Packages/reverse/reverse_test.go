@@ -0,0 +1,129 @@
+package reverse
+
+import (
+    "testing"
+    "fmt"
+    "math"
+)
+
+// centralDifference approximates the gradient of f at x with central differences
+func centralDifference(f func([]float64) float64, x []float64) []float64 {
+    g := make([]float64, len(x))
+    for i := range x {
+        h := 1e-6*math.Max(math.Abs(x[i]), 1.0)
+        xi := x[i]
+        x[i] = xi + h
+        fp := f(x)
+        x[i] = xi - h
+        fm := f(x)
+        x[i] = xi
+        g[i] = (fp - fm)/(2.0*h)
+    }
+    return g
+}
+
+// TestGradientRosenbrock calls reverse.Differentiate with the Rosenbrock function, checking
+// the gradient against finite differences.
+func TestGradientRosenbrock(t *testing.T) {
+    es := 1e-5
+    f, grad := Differentiate(func(t *Tape, x []Var) Var {
+        a := t.Const(1.0).Sub(x[0])
+        b := x[1].Sub(x[0].Mul(x[0]))
+        return a.Mul(a).Add(b.Mul(b).Scale(100.0))
+    })
+    for _, x := range [][]float64{{-1.2, 1.0}, {0.0, 0.0}, {1.0, 1.0}, {2.0, -3.0}} {
+        g := grad(x)
+        gwant := centralDifference(f, x)
+        for i := range gwant {
+            if math.Abs(g[i] - gwant[i]) > es*math.Max(math.Abs(gwant[i]), 1.0) {
+                t.Fatalf(`Differentiate(rosenbrock) gradient at %v = %v, want %v`, x, g, gwant)
+            }
+        }
+    }
+}
+
+// TestGradientElementary calls reverse.Differentiate with a function of three variables built from
+// elementary functions, checking the value and the gradient against finite differences.
+func TestGradientElementary(t *testing.T) {
+    es := 1e-6
+    f, grad := Differentiate(func(t *Tape, x []Var) Var {
+        p := Sin(x[0]).Mul(Exp(x[1].Scale(0.5)))
+        q := Log(x[2]).Div(Sqrt(x[0].Mul(x[0]).Add(x[2])))
+        r := Pow(Tanh(x[1]), 3.0).Add(Atan(x[0].Mul(x[2])))
+        return Sum([]Var{p, q, r, Cos(x[1]).Neg()})
+    })
+    x := []float64{0.3, -0.8, 1.7}
+    fxwant := math.Sin(x[0])*math.Exp(0.5*x[1]) + math.Log(x[2])/math.Sqrt(x[0]*x[0] + x[2]) +
+        math.Pow(math.Tanh(x[1]), 3.0) + math.Atan(x[0]*x[2]) - math.Cos(x[1])
+    fx := f(x)
+    g := grad(x)
+    gwant := centralDifference(f, x)
+    msg := fmt.Sprintf("%f, %v", fx, g)
+    want := fmt.Sprintf("%f, %v", fxwant, gwant)
+    if math.Abs(fx - fxwant) > 1e-12 {
+        t.Fatalf(`Differentiate(f)(x) = %q, want %q`, msg, want)
+    }
+    for i := range g {
+        if math.Abs(g[i] - gwant[i]) > es {
+            t.Fatalf(`Differentiate(f)(x) = %q, want %q`, msg, want)
+        }
+    }
+}
+
+// TestGradientForeignTape calls Tape.Gradient with a variable from another tape, checking for an error.
+func TestGradientForeignTape(t *testing.T) {
+    t1 := NewTape()
+    t2 := NewTape()
+    y := t2.Var(1.0).Scale(2.0)
+    grad, err := t1.Gradient(y)
+    if grad != nil || err == nil {
+        t.Fatalf(`Gradient(y from other tape) = %v, %v, want nil, error`, grad, err)
+    }
+}
+
+// TestDifferentiateForeignTape calls reverse.Differentiate with a function returning a variable of another tape,
+// checking that the gradient panics like mixing tapes in an operation does.
+func TestDifferentiateForeignTape(t *testing.T) {
+    other := NewTape()
+    _, grad := Differentiate(func(t *Tape, x []Var) Var {
+        return other.Var(x[0].Value())
+    })
+    defer func() {
+        if recover() == nil {
+            t.Fatalf(`Differentiate(f returning other tape)([1]) did not panic`)
+        }
+    }()
+    grad([]float64{1.0})
+}
+
+// TestMixedTapes calls Add with variables of two tapes, checking for a panic.
+func TestMixedTapes(t *testing.T) {
+    defer func() {
+        if recover() == nil {
+            t.Fatalf(`Add(x, y from other tape) did not panic`)
+        }
+    }()
+    NewTape().Var(1.0).Add(NewTape().Var(2.0))
+}
+
+// TestPowZero calls reverse.Pow with the exponents 0, 1, 2 and 3 at x = 0, checking for exact values and
+// derivatives instead of NaN.
+func TestPowZero(t *testing.T) {
+    for _, p := range []float64{0.0, 1.0, 2.0, 3.0} {
+        tape := NewTape()
+        x := tape.Var(0.0)
+        y := Pow(x, p).Add(x)
+        g, err := tape.Gradient(y)
+        want := 1.0 // d/dx (x**p + x) at 0
+        if p == 1.0 {
+            want = 2.0
+        }
+        fwant := 0.0
+        if p == 0.0 {
+            fwant = 1.0
+        }
+        if err != nil || y.Value() != fwant || g[0] != want {
+            t.Fatalf(`Pow(0, %g) + 0 = %v, gradient %v, %v, want %v, [%v], nil`, p, y.Value(), g, err, fwant, want)
+        }
+    }
+}
@@ -83,6 +83,41 @@ func Newtraph(f func(float64) float64, df func(float64) float64, xr float64, es
     return root, fx, ea, iter, nil
 }
 
+// NewtraphComplex (Newton-Raphson with complex-step derivative)
+// The derivative is computed as Im(f(x + ih))/h, which is exact to machine precision for analytic f
+// input:
+// the function to find the root for extended to complex arguments (f), initial guess (xr), error deviation (es), maximum iterations (iter)
+// output: 
+// the estimated root (root), function value (fx), error estimate (ea), iterations done (iter)
+func NewtraphComplex(f func(complex128) complex128, xr float64, es float64, maxit int) (root float64, fx float64, ea float64, iter int, err error) {
+    if es < 0.0 {
+        return 0.0, 0.0, 0.0, 0, errors.New("es must be greater than 0")
+    }
+    iter = 0
+    var xrold, h, df float64
+    var fc complex128
+    ea = 100
+    for ; iter < maxit; iter ++ {
+        xrold = xr
+        h = 1e-20*math.Max(math.Abs(xr), 1.0)
+        fc = f(complex(xr, h))
+        df = imag(fc)/h
+        if df == 0.0 {
+            return xr, real(f(complex(xr, 0.0))), ea, iter, errors.New("Derivative is zero")
+        }
+        xr -= real(fc)/df
+        if xr != 0 {
+            ea = math.Abs((xr - xrold) / xr) * 100.0
+        }
+        if ea <= es {
+            break;
+        }
+    }
+    root = xr
+    fx = real(f(complex(xr, 0.0)))
+    return root, fx, ea, iter, nil
+}
+
 // Secant (Variation of Newton-Raphson)
 // input:
 // the function to find the root for (f), pertubation fraction (p), initial guess (xr), error deviation (es), maximum iterations (iter)
@@ -4,6 +4,7 @@ import (
     "testing"
     "reflect"
     "fmt"
+    "math/cmplx"
 )
 
 // TestLinspace calls rootmethods.Linspace with a start, stop and numsteps, checking 
@@ -95,6 +96,39 @@ func TestNewtraph(t *testing.T) {
     }
 }
 
+// TestNewtraphComplex calls rootmethods.NewtraphComplex with a complex function, initial guess, error limit and max iterations, checking 
+// for a valid return value.
+func TestNewtraphComplex(t *testing.T) {
+    xr := 1.0
+    es := 0.0001
+    maxit := 50
+    f := func(x complex128) complex128 {
+        return cmplx.Cos(x) - x
+    }
+    rootwant := 0.739085
+    fxwant := 0.0
+    root, fx, ea, iter, err := NewtraphComplex(f, xr, es, maxit)
+    msg := fmt.Sprintf("%f, %f, %f, %d", root, fx, ea, iter)
+    want := fmt.Sprintf("%f, %f, %f, %d < %d", rootwant, fxwant, 0.0, iter, maxit)
+    rootwithininterval := (root <= rootwant + es) && (root >= rootwant - es)
+    fxwithininterval := (fx <= fxwant + es) && (fx >= fxwant - es)
+    if !rootwithininterval || !fxwithininterval || ea > es || iter > maxit || err != nil {
+        t.Fatalf(`NewtraphComplex(f: x->cos(x)-x, 1, 0.0001, 50) = %q, %v, want match for %#v, nil`, msg, err, want)
+    }
+}
+
+// TestNewtraphComplexZeroDerivative calls rootmethods.NewtraphComplex at a stationary point, 
+// checking for an error.
+func TestNewtraphComplexZeroDerivative(t *testing.T) {
+    f := func(x complex128) complex128 {
+        return x*x + 1.0
+    }
+    root, fx, ea, iter, err := NewtraphComplex(f, 0.0, 0.0001, 50)
+    if err == nil {
+        t.Fatalf(`NewtraphComplex(f: x->x^2+1, 0, 0.0001, 50) = %f, %f, %f, %d, %v, want error`, root, fx, ea, iter, err)
+    }
+}
+
 // TestSecant calls rootmethods.Secant with a function, pertubation fraction, x upper, error limit and max iterations, checking 
 // for a valid return value.
 func TestSecant(t *testing.T) {
@@ -3,8 +3,13 @@ package main
 import (
 	"fmt"
 	"math"
+	"os"
 	"example.com/rootmethods"
 	"example.com/optimization"
+	"example.com/dual"
+	"example.com/reverse"
+	"example.com/numdiff"
+	"example.com/linprog"
 )
 
 func main() {
@@ -33,6 +38,13 @@ func main() {
     }
     root, fx, ea, iter, err = rootmethods.Newtraph(f, df, xr, es, maxit)
     fmt.Println(root, fx, ea, iter, err)
+    fmt.Println("\nNewtraphComplex")
+    // NewtraphComplex
+    fc := func(x complex128) complex128 {
+        return 2.0*x - 3.0
+    }
+    root, fx, ea, iter, err = rootmethods.NewtraphComplex(fc, xr, es, maxit)
+    fmt.Println(root, fx, ea, iter, err)
     fmt.Println("\nSecant")
     // Secant
     p := 1e-6
@@ -65,4 +77,216 @@ func main() {
     xm := 1.0
     root, fx, ea, iter, err = optimization.Parabolic(f, xl, xm, xu, es, maxit)
     fmt.Println(root, fx, ea, iter, err)
-}
+    fmt.Println("\nBrentmin")
+    // Brentmin
+    root, fx, ea, iter, err = optimization.Brentmin(f, xl, xu, es, maxit)
+    fmt.Println(root, fx, ea, iter, err)
+    fmt.Println("\nDbrentmin")
+    // Dbrentmin
+    df = func(x float64) float64 {
+        return x/5.0 - 2.0*math.Cos(x)
+    }
+    root, fx, ea, iter, err = optimization.Dbrentmin(f, df, xl, xu, es, maxit)
+    fmt.Println(root, fx, ea, iter, err)
+    fmt.Println("\nMnbrak")
+    // Mnbrak
+    bl, bm, bu, fl, fm, fu, err := optimization.Mnbrak(f, -1.0, -0.5, maxit)
+    fmt.Println(bl, bm, bu, fl, fm, fu, err)
+    fmt.Println("\nGoldminAuto")
+    // GoldminAuto
+    root, fx, ea, iter, err = optimization.GoldminAuto(f, -1.0, -0.5, es, maxit)
+    fmt.Println(root, fx, ea, iter, err)
+    fmt.Println("\nParabolicAuto")
+    // ParabolicAuto
+    root, fx, ea, iter, err = optimization.ParabolicAuto(f, -1.0, -0.5, es, maxit)
+    fmt.Println(root, fx, ea, iter, err)
+    fmt.Println("\n\nMultivariate optimization")
+    fmt.Println("\nNelderMead")
+    // NelderMead
+    rosenbrock := func(x []float64) float64 {
+        return (1.0 - x[0])*(1.0 - x[0]) + 100.0*(x[1] - x[0]*x[0])*(x[1] - x[0]*x[0])
+    }
+    x0 := []float64{-1.2, 1.0}
+    xv, fx, ea, iter, err := optimization.NelderMead(rosenbrock, x0, 0.1, 1, 1e-10, 2000)
+    fmt.Println(xv, fx, ea, iter, err)
+    fmt.Println("\nBFGS")
+    // BFGS, the gradient is approximated with finite differences when nil
+    xv, fx, ea, iter, err = optimization.BFGS(rosenbrock, nil, x0, nil, 1e-6, 200)
+    fmt.Println(xv, fx, ea, iter, err)
+    fmt.Println("\nLBFGS")
+    // LBFGS
+    xv, fx, ea, iter, err = optimization.LBFGS(rosenbrock, nil, x0, 5, nil, 1e-6, 200)
+    fmt.Println(xv, fx, ea, iter, err)
+    fmt.Println("\nConjugateGradient")
+    // ConjugateGradient
+    xv, fx, ea, iter, err = optimization.ConjugateGradient(rosenbrock, nil, x0, optimization.CGPolakRibierePlus, nil, 1e-6, 200)
+    fmt.Println(xv, fx, ea, iter, err)
+    fmt.Println("\nNewton")
+    // Newton, the Hessian is approximated with finite differences when nil
+    xv, fx, ea, iter, err = optimization.Newton(rosenbrock, nil, nil, x0, nil, 1e-6, 100)
+    fmt.Println(xv, fx, ea, iter, err)
+    fmt.Println("\nTrustRegionNewton")
+    // TrustRegionNewton
+    xv, fx, ea, iter, err = optimization.TrustRegionNewton(rosenbrock, nil, nil, x0, 1.0, 1e-6, 100)
+    fmt.Println(xv, fx, ea, iter, err)
+    fmt.Println("\nLBFGSB")
+    // LBFGSB with x1 <= 0.5
+    xv, fx, ea, iter, err = optimization.LBFGSB(rosenbrock, nil, x0, []float64{-2.0, -2.0}, []float64{0.5, 2.0}, 5, 1e-6, 200)
+    fmt.Println(xv, fx, ea, iter, err)
+    fmt.Println("\nLevenbergMarquardt")
+    // LevenbergMarquardt fitting y = a*exp(-b*t), the Jacobian is approximated with finite differences when nil
+    ts := []float64{0.0, 0.5, 1.0, 1.5, 2.0, 2.5, 3.0}
+    ys := []float64{3.02, 2.21, 1.64, 1.22, 0.90, 0.67, 0.50}
+    residuals := func(theta []float64) []float64 {
+        res := make([]float64, len(ts))
+        for i := range ts {
+            res[i] = theta[0]*math.Exp(-theta[1]*ts[i]) - ys[i]
+        }
+        return res
+    }
+    theta, fx, ea, iter, err := optimization.LevenbergMarquardt(residuals, nil, []float64{1.0, 1.0}, true, 1e-10, 100)
+    fmt.Println(theta, fx, ea, iter, err)
+    stats, err := optimization.FitStatistics(residuals, nil, theta)
+    fmt.Println(stats.ReducedChi2, stats.StdErr, err)
+    fmt.Println("\nAugmentedLagrangian")
+    // AugmentedLagrangian for min x0 + x1 subject to x0^2 + x1^2 <= 2 and x0 = x1, nil Jacobians use finite differences
+    linear := func(x []float64) float64 {
+        return x[0] + x[1]
+    }
+    disk := func(x []float64) []float64 {
+        return []float64{x[0]*x[0] + x[1]*x[1] - 2.0}
+    }
+    diagonal := func(x []float64) []float64 {
+        return []float64{x[0] - x[1]}
+    }
+    xv, fx, lambda, mu, ea, iter, err := optimization.AugmentedLagrangian(linear, nil, disk, nil, diagonal, nil, nil, []float64{0.5, 0.0}, 1e-6, 50)
+    fmt.Println(xv, fx, lambda, mu, ea, iter, err)
+    fmt.Println("\nSQP")
+    // SQP
+    xv, fx, lambda, mu, ea, iter, err = optimization.SQP(linear, nil, disk, nil, diagonal, nil, []float64{0.5, 0.0}, 1e-8, 100)
+    fmt.Println(xv, fx, lambda, mu, ea, iter, err)
+    fmt.Println("\nActiveSetQP")
+    // ActiveSetQP for a minimum variance portfolio with a return of at least 9% and no short selling
+    cov := [][]float64{{0.10, 0.02, 0.04}, {0.02, 0.08, 0.01}, {0.04, 0.01, 0.20}}
+    zero := []float64{0.0, 0.0, 0.0}
+    Aq := [][]float64{{-0.08, -0.05, -0.15}, {-1.0, 0.0, 0.0}, {0.0, -1.0, 0.0}, {0.0, 0.0, -1.0}}
+    bq := []float64{-0.09, 0.0, 0.0, 0.0}
+    Aeq := [][]float64{{1.0, 1.0, 1.0}}
+    beq := []float64{1.0}
+    xv, fx, yq, active, ray, err := optimization.ActiveSetQP(cov, zero, Aq, bq, Aeq, beq, nil)
+    fmt.Println(xv, fx, yq, active, ray, err)
+    fmt.Println("\nADMMQP")
+    // ADMMQP
+    xv, fx, yq, ray, ea, iter, err = optimization.ADMMQP(cov, zero, Aq, bq, Aeq, beq, nil, nil, 0.1, 1e-8, 5000)
+    fmt.Println(xv, fx, yq, ray, ea, iter, err)
+    fmt.Println("\n\nGlobal optimization")
+    fmt.Println("\nSimulatedAnnealing")
+    // SimulatedAnnealing on the Rastrigin function, the seed makes the run reproducible
+    rastrigin := func(x []float64) float64 {
+        s := 20.0
+        for _, v := range x {
+            s += v*v - 10.0*math.Cos(2.0*math.Pi*v)
+        }
+        return s
+    }
+    xv, fx, ea, iter, err = optimization.SimulatedAnnealing(rastrigin, []float64{3.2, -4.1}, optimization.CauchyNeighbor(0.3), optimization.GeometricCooling(0.999), 10.0, 7, 1e-3, 20000)
+    fmt.Println(xv, fx, ea, iter, err)
+    fmt.Println("\nDifferentialEvolution")
+    // DifferentialEvolution with SHADE adaptation in [-5.12, 5.12]^2, evaluating the population with 4 goroutines
+    lower := []float64{-5.12, -5.12}
+    upper := []float64{5.12, 5.12}
+    xv, fx, ea, iter, err = optimization.DifferentialEvolution(rastrigin, lower, upper, 20, optimization.DECurrentToBest1Bin, optimization.DESHADE, 0.5, 0.5, 1, 4, 1e-10, 1000)
+    fmt.Println(xv, fx, ea, iter, err)
+    fmt.Println("\nParticleSwarm")
+    // ParticleSwarm with constriction, ring topology and velocities limited to 20% of the box
+    xv, fx, ea, iter, err = optimization.ParticleSwarm(rastrigin, lower, upper, 30, optimization.PSOConstriction, optimization.PSORing, optimization.PSOReflect, 0.2, 1, 4, 1e-10, 3000)
+    fmt.Println(xv, fx, ea, iter, err)
+    fmt.Println("\nCMAES")
+    // CMAES with IPOP restarts in the box, the diagnostics describe the final search distribution
+    xv, fx, cma, ea, iter, err := optimization.CMAES(rastrigin, []float64{3.0, 3.0}, 2.0, lower, upper, 0, optimization.CMAIPOP, 4, 1, 4, 1e-10, 5000)
+    fmt.Println(xv, fx, ea, iter, err)
+    fmt.Println(cma.Sigma, cma.Condition, cma.Population, cma.Restarts, cma.Evaluations)
+    fmt.Println("\nMultiStart")
+    // MultiStart running BFGS from 50 Sobol points, listing the distinct local minima of rastrigin
+    bfgs := func(f func([]float64) float64, x0 []float64) ([]float64, float64, float64, int, error) {
+        return optimization.BFGS(f, nil, x0, nil, 1e-8, 200)
+    }
+    xv, fx, minima, err := optimization.MultiStart(rastrigin, bfgs, lower, upper, 50, optimization.SobolSampling, 1e-3, 1, 4)
+    fmt.Println(xv, fx, len(minima), err)
+    for _, m := range minima[:3] {
+        fmt.Println(m.X, m.Fx, m.Count)
+    }
+    fmt.Println("\nPiyavskii")
+    // Piyavskii with the Lipschitz constant 1 + 10/3, ea bounds the distance from the global minimum
+    x, fx, ea, iter, err := optimization.Piyavskii(func(x float64) float64 {
+        return math.Sin(x) + math.Sin(10.0*x/3.0)
+    }, 2.7, 7.5, 1.0 + 10.0/3.0, 1e-4, 1000)
+    fmt.Println(x, fx, ea, iter, err)
+    fmt.Println("\nDIRECT")
+    // DIRECT until every point of the box is within 0.02 of a sample in unit coordinates
+    xv, fx, ea, iter, err = optimization.DIRECT(rastrigin, lower, upper, 0.02, 1000)
+    fmt.Println(xv, fx, ea, iter, err)
+    fmt.Println("\nNSGA2")
+    // NSGA2 trading off the squared distances to (0, 0) and (2, 2), the front is the segment between them
+    X, F, iter, err := optimization.NSGA2(func(x []float64) []float64 {
+        return []float64{x[0]*x[0] + x[1]*x[1], (x[0] - 2.0)*(x[0] - 2.0) + (x[1] - 2.0)*(x[1] - 2.0)}
+    }, []float64{0.0, 0.0}, []float64{2.0, 2.0}, 20, 20.0, 20.0, 1, 4, 100)
+    hv, _ := optimization.Hypervolume(F, []float64{8.0, 8.0})
+    fmt.Println(len(F), hv, iter, err)
+    err = optimization.WriteFrontCSV(os.Stdout, X[:3], F[:3])
+    fmt.Println(err)
+    fmt.Println("\n\nAutomatic differentiation")
+    fmt.Println("\nNewtraph with dual numbers")
+    // Newtraph with the derivative obtained from dual numbers
+    xr = 1.0
+    es = 1e-6
+    f, df = dual.Differentiate(func(x dual.Dual) dual.Dual {
+        return dual.Cos(x).Sub(x)
+    })
+    root, fx, ea, iter, err = rootmethods.Newtraph(f, df, xr, es, maxit)
+    fmt.Println(root, fx, ea, iter, err)
+    fmt.Println("\nGradientDescent with reverse-mode gradient")
+    // GradientDescent with the gradient obtained from the tape
+    fv, grad := reverse.Differentiate(func(t *reverse.Tape, x []reverse.Var) reverse.Var {
+        a := x[0].Shift(-1.0)
+        b := x[1].Shift(2.0)
+        return a.Mul(a).Add(b.Mul(b).Scale(4.0))
+    })
+    xv, fx, ea, iter, err = optimization.GradientDescent(fv, grad, []float64{5.0, 5.0}, es, 1000)
+    fmt.Println(xv, fx, ea, iter, err)
+    fmt.Println("\nGradientDescentLineSearch")
+    // GradientDescentLineSearch with the More-Thuente line search
+    xv, fx, ea, iter, err = optimization.GradientDescentLineSearch(fv, grad, []float64{5.0, 5.0}, optimization.MoreThuente(1e-4, 0.9), es, 1000)
+    fmt.Println(xv, fx, ea, iter, err)
+    fmt.Println("\n\nNumerical differentiation")
+    fmt.Println("\nRichardson")
+    // Richardson
+    f = func(x float64) float64 {
+        return (x*x)/10.0 - 2.0*math.Sin(x)
+    }
+    d, ea, iter, err := numdiff.Richardson(f, 1.0, 0.5, 1, 1e-12, 20)
+    fmt.Println(d, ea, iter, err)
+    fmt.Println("\n\nLinear programming")
+    fmt.Println("\nSimplex")
+    // Simplex for max 3 x0 + 5 x1 subject to x0 <= 4, 2 x1 <= 12 and 3 x0 + 2 x1 <= 18
+    c := []float64{-3.0, -5.0}
+    A := [][]float64{{1.0, 0.0}, {0.0, 2.0}, {3.0, 2.0}}
+    b := []float64{4.0, 12.0, 18.0}
+    xv, fx, y, rc, iter, err := linprog.Simplex(c, A, b, nil, nil, 100)
+    fmt.Println(xv, fx, y, rc, iter, err)
+    fmt.Println("\nInteriorPoint")
+    // InteriorPoint
+    xv, fx, y, rc, ea, iter, err = linprog.InteriorPoint(c, A, b, nil, nil, 1e-9, 100)
+    fmt.Println(xv, fx, y, rc, ea, iter, err)
+    fmt.Println("\nBranchAndBound")
+    // BranchAndBound for a knapsack with binary variables, printing every new incumbent
+    weights := [][]float64{{3.0, 4.0, 2.0, 3.0}}
+    values := []float64{-10.0, -13.0, -7.0, -8.0}
+    types := []linprog.VarType{linprog.Binary, linprog.Binary, linprog.Binary, linprog.Binary}
+    incumbent := func(x []float64, fx float64) bool {
+        fmt.Println("incumbent", x, fx)
+        return false
+    }
+    xv, fx, bound, nodes, err := linprog.BranchAndBound(values, weights, []float64{7.0}, nil, nil, types, linprog.BestBound, incumbent, 0.0, 4, 1000)
+    fmt.Println(xv, fx, bound, nodes, err)
+}
\ No newline at end of file
@@ -6,7 +6,19 @@ replace example.com/rootmethods => ../Packages/rootmethods
 
 replace example.com/optimization => ../Packages/optimization
 
+replace example.com/dual => ../Packages/dual
+
+replace example.com/reverse => ../Packages/reverse
+
+replace example.com/numdiff => ../Packages/numdiff
+
+replace example.com/linprog => ../Packages/linprog
+
 require (
-	example.com/optimization v0.0.0-00010101000000-000000000000 // indirect
+	example.com/dual v0.0.0-00010101000000-000000000000
+	example.com/linprog v0.0.0-00010101000000-000000000000
+	example.com/numdiff v0.0.0-00010101000000-000000000000
+	example.com/optimization v0.0.0-00010101000000-000000000000
+	example.com/reverse v0.0.0-00010101000000-000000000000
 	example.com/rootmethods v0.0.0-00010101000000-000000000000
 )